@@ -50,19 +50,35 @@ type DropletParameters struct {
 	// +immutable
 	Image string `json:"image"`
 
+	// OneClick: A boolean indicating whether Image is the slug of a 1-Click
+	// application from the DigitalOcean Marketplace. If true, the slug is
+	// checked against the available 1-Click applications before the Droplet
+	// is created.
+	// +optional
+	// +immutable
+	OneClick *bool `json:"oneClick,omitempty"`
+
 	// SSHKeys: An array containing the IDs or fingerprints of the SSH keys
 	// that you wish to embed in the Droplet's root account upon creation.
+	// Prefer setting them: without SSH keys DigitalOcean emails a root
+	// password in plain text to the account owner and enables password
+	// logins over SSH. The API never returns that password, so it can't be
+	// written to the connection secret.
 	// +optional
 	// +immutable
 	SSHKeys []string `json:"sshKeys,omitempty"`
 
 	// Backups: A boolean indicating whether automated backups should be enabled
-	// for the Droplet. Automated backups can only be enabled when the Droplet is
-	// created.
+	// for the Droplet. Backups are enabled on an existing Droplet when this is
+	// set to true, but are never disabled when it is set to false again.
 	// +optional
-	// +immutable
 	Backups *bool `json:"backups,omitempty"`
 
+	// BackupPolicy: When and how often automated backups are taken. Requires
+	// backups to be enabled. DigitalOcean's default policy is used if unset.
+	// +optional
+	BackupPolicy *DropletBackupPolicy `json:"backupPolicy,omitempty"`
+
 	// IPv6: A boolean indicating whether IPv6 is enabled on the Droplet.
 	// +optional
 	// +immutable
@@ -76,7 +92,8 @@ type DropletParameters struct {
 	PrivateNetworking *bool `json:"privateNetworking,omitempty"`
 
 	// Monitoring: A boolean indicating whether to install the DigitalOcean
-	// agent for monitoring.
+	// agent for monitoring. The agent can only be installed when the Droplet
+	// is created.
 	// +optional
 	// +immutable
 	Monitoring *bool `json:"monitoring,omitempty"`
@@ -93,12 +110,19 @@ type DropletParameters struct {
 	// +immutable
 	Volumes []string `json:"volumes,omitempty"`
 
-	// Tags: A flat array of tag names as strings to apply to the Droplet after it
-	// is created. Tag names can either be existing or new tags.
+	// Tags: A flat array of tag names as strings to apply to the Droplet. Tag
+	// names can either be existing or new tags. Tags that were applied to the
+	// Droplet outside of the provider are kept.
 	// +optional
-	// +immutable
 	Tags []string `json:"tags,omitempty"`
 
+	// IgnoreTagsMatching: Tags that are managed outside of the provider,
+	// e.g. by billing automation. Matching tags are never added to or removed
+	// from the Droplet and don't count as drift. An entry matches a tag exactly,
+	// or, if it ends with "*", any tag starting with it, e.g. "billing:*".
+	// +optional
+	IgnoreTagsMatching []string `json:"ignoreTagsMatching,omitempty"`
+
 	// VPCUUID: A string specifying the UUID of the VPC to which the Droplet
 	// will be assigned. If excluded, beginning on April 7th, 2020, the Droplet
 	// will be assigned to your account's default VPC for the region.
@@ -113,6 +137,32 @@ type DropletParameters struct {
 	// +optional
 	// +immutable
 	WithDropletAgent *bool `json:"withDropletAgent,omitempty"`
+
+	// DeleteAssociatedResources: A boolean indicating whether the volumes,
+	// volume snapshots, snapshots and reserved IPs associated with the Droplet
+	// are destroyed along with it. They are kept by default.
+	// +optional
+	DeleteAssociatedResources *bool `json:"deleteAssociatedResources,omitempty"`
+}
+
+// DropletBackupPolicy defines when automated backups of a Droplet are taken.
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/dropletActions_post
+type DropletBackupPolicy struct {
+	// Plan: How often backups are taken, either "daily" or "weekly".
+	// +kubebuilder:validation:Enum=daily;weekly
+	Plan string `json:"plan"`
+
+	// Weekday: The day of the week weekly backups are taken on, e.g. "SUN".
+	// Only valid for the "weekly" plan.
+	// +optional
+	// +kubebuilder:validation:Enum=SUN;MON;TUE;WED;THU;FRI;SAT
+	Weekday *string `json:"weekday,omitempty"`
+
+	// Hour: The hour of the day, in UTC, at which the four hour backup
+	// window starts. Must be one of 0, 4, 8, 12, 16 or 20.
+	// +optional
+	// +kubebuilder:validation:Enum=0;4;8;12;16;20
+	Hour *int `json:"hour,omitempty"`
 }
 
 // A DropletObservation reflects the observed state of a Droplet on DigitalOcean.
@@ -135,6 +185,16 @@ type DropletObservation struct {
 	// Resource size slug.
 	Size string `json:"size,omitempty"`
 
+	// Slug of the 1-Click application the Droplet was deployed from.
+	OneClickApp string `json:"oneClickApp,omitempty"`
+
+	// Backups indicates whether automated backups are enabled for the Droplet.
+	Backups bool `json:"backups,omitempty"`
+
+	// Monitoring indicates whether the DigitalOcean agent for monitoring is
+	// installed on the Droplet.
+	Monitoring bool `json:"monitoring,omitempty"`
+
 	// A Status string indicating the state of the Droplet instance.
 	//
 	// Possible values:
@@ -155,6 +215,22 @@ type DropletSpec struct {
 type DropletStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          DropletObservation `json:"atProvider,omitempty"`
+
+	// ConnectionChecksum is the SHA-256 checksum of the connection secret,
+	// as of the last time connection details were written to it. Tooling
+	// can watch it to learn that the connection details changed.
+	// +optional
+	ConnectionChecksum string `json:"connectionChecksum,omitempty"`
+}
+
+// GetConnectionChecksum of this Droplet.
+func (mg *Droplet) GetConnectionChecksum() string {
+	return mg.Status.ConnectionChecksum
+}
+
+// SetConnectionChecksum of this Droplet.
+func (mg *Droplet) SetConnectionChecksum(sum string) {
+	mg.Status.ConnectionChecksum = sum
 }
 
 // +kubebuilder:object:root=true
@@ -52,6 +52,31 @@ func (in *Droplet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DropletBackupPolicy) DeepCopyInto(out *DropletBackupPolicy) {
+	*out = *in
+	if in.Weekday != nil {
+		in, out := &in.Weekday, &out.Weekday
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hour != nil {
+		in, out := &in.Hour, &out.Hour
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletBackupPolicy.
+func (in *DropletBackupPolicy) DeepCopy() *DropletBackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DropletBackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DropletList) DeepCopyInto(out *DropletList) {
 	*out = *in
@@ -102,6 +127,11 @@ func (in *DropletObservation) DeepCopy() *DropletObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DropletParameters) DeepCopyInto(out *DropletParameters) {
 	*out = *in
+	if in.OneClick != nil {
+		in, out := &in.OneClick, &out.OneClick
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SSHKeys != nil {
 		in, out := &in.SSHKeys, &out.SSHKeys
 		*out = make([]string, len(*in))
@@ -112,6 +142,11 @@ func (in *DropletParameters) DeepCopyInto(out *DropletParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BackupPolicy != nil {
+		in, out := &in.BackupPolicy, &out.BackupPolicy
+		*out = new(DropletBackupPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IPv6 != nil {
 		in, out := &in.IPv6, &out.IPv6
 		*out = new(bool)
@@ -142,6 +177,11 @@ func (in *DropletParameters) DeepCopyInto(out *DropletParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IgnoreTagsMatching != nil {
+		in, out := &in.IgnoreTagsMatching, &out.IgnoreTagsMatching
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.VPCUUID != nil {
 		in, out := &in.VPCUUID, &out.VPCUUID
 		*out = new(string)
@@ -152,6 +192,11 @@ func (in *DropletParameters) DeepCopyInto(out *DropletParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DeleteAssociatedResources != nil {
+		in, out := &in.DeleteAssociatedResources, &out.DeleteAssociatedResources
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletParameters.
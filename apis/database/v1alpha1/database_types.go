@@ -28,6 +28,32 @@ const (
 	StatusForking   = "forking"
 )
 
+// Known Database Cluster engine slugs
+const (
+	EnginePostgreSQL = "pg"
+	EngineMySQL      = "mysql"
+	EngineRedis      = "redis"
+	EngineMongoDB    = "mongodb"
+)
+
+// Known Database Cluster connection endpoints and SSL modes
+const (
+	EndpointPublic  = "public"
+	EndpointPrivate = "private"
+
+	SSLModeDisable    = "disable"
+	SSLModeRequire    = "require"
+	SSLModeVerifyFull = "verify-full"
+)
+
+// Known Database Cluster online migration statuses
+const (
+	MigrationStatusSyncing  = "syncing"
+	MigrationStatusDone     = "done"
+	MigrationStatusCanceled = "canceled"
+	MigrationStatusError    = "error"
+)
+
 // A DODatabaseClusterParameters defines the desired state of a DigitalOcean Database Cluster.
 // All fields map directly to a Database Cluster
 // https://docs.digitalocean.com/reference/api/api-reference/#operation/create_database_cluster
@@ -63,6 +89,256 @@ type DODatabaseClusterParameters struct {
 	// +optional
 	// +immutable
 	Tags []string `json:"tags,omitempty"`
+
+	// InitialDatabaseName: The name of a logical database to create in the cluster once it is online, in
+	// addition to the default database DigitalOcean creates. The connection details target it unless
+	// connection.database is set. Only supported for the "pg" and "mysql" engines (Optional).
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:MaxLength=63
+	InitialDatabaseName *string `json:"initialDatabaseName,omitempty"`
+
+	// Users: The users of the cluster. Once the cluster is online, listed users that don't exist are
+	// created and users that aren't listed are deleted, apart from the "doadmin" admin user. The password
+	// of each listed user is written to the connection secret under the "<name>.password" key. Users
+	// aren't managed unless at least one is listed (Optional).
+	// +optional
+	Users []DODatabaseClusterUserParameters `json:"users,omitempty"`
+
+	// Connection: Configures the connection details that are written to the connection secret (Optional).
+	// +optional
+	Connection *DODatabaseClusterConnectionParameters `json:"connection,omitempty"`
+
+	// Metrics: Writes the credentials and endpoints of the cluster's metrics, e.g. for Prometheus to scrape,
+	// to a secret of their own. The metrics credentials are shared by all clusters of the account. The endpoint
+	// keys are omitted if the cluster's engine doesn't expose metrics (Optional).
+	// +optional
+	Metrics *DODatabaseClusterMetricsParameters `json:"metrics,omitempty"`
+
+	// OnlineMigration: Migrates the data of an existing external database into the cluster once it is online.
+	// The migration is only started once; it is not restarted after it has finished, failed or been canceled (Optional).
+	// +optional
+	OnlineMigration *DODatabaseClusterOnlineMigrationParameters `json:"onlineMigration,omitempty"`
+
+	// Fork: Creates the cluster as a fork of an existing Database Cluster by restoring one of its backups.
+	// It is only used to create the cluster and ignored afterwards (Optional).
+	// +optional
+	// +immutable
+	Fork *DODatabaseClusterForkParameters `json:"fork,omitempty"`
+
+	// Paused: When true the cluster is resized to a single node of the smallest size that isn't smaller
+	// than its current size to reduce cost, and resized back to its previous size and node count when set
+	// to false again. DigitalOcean can't stop a cluster, so it keeps running and holding its data while
+	// paused. Data is preserved, but resizing causes a short period of unavailability in both directions
+	// and standby nodes are removed while paused. A cluster that has no such size is left as is, and its
+	// Paused condition says why (Optional).
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+
+	// Config: Advanced, engine specific configuration of the cluster. Only the settings that are specified
+	// are managed; all others keep the values DigitalOcean chose. Configuration of another engine than the
+	// cluster's, or of both engines, is rejected (Optional).
+	// +optional
+	Config *DODatabaseClusterConfig `json:"config,omitempty"`
+
+	// PublicAccess: When false, a trusted source for the IP range of the cluster's VPC is added so that
+	// resources in the VPC can connect, and the private endpoint is written to the connection secret. A
+	// cluster with trusted sources only accepts connections from them. When true, that trusted source is
+	// removed again. Other trusted sources are left alone either way. Trusted sources are left as they
+	// are when unset (Optional).
+	// +optional
+	PublicAccess *bool `json:"publicAccess,omitempty"`
+
+	// TrustedDropletTags: Tags of the Droplets that may connect to the cluster. Each tag is translated
+	// into a trusted source of type tag, and trusted sources of type tag that aren't listed are removed.
+	// Other trusted sources are left alone. Trusted sources of type tag aren't managed unless at least
+	// one tag is listed, or tags were listed before; removing all tags removes their trusted sources (Optional).
+	// +optional
+	TrustedDropletTags []string `json:"trustedDropletTags,omitempty"`
+}
+
+// DODatabaseClusterMetricsParameters configure where the metrics credentials of a Database Cluster are written.
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/databases_get_cluster_metrics_credentials
+type DODatabaseClusterMetricsParameters struct {
+	// WriteSecretToReference: The secret the metrics credentials and endpoints are written to. It must not
+	// be the connection secret of the cluster.
+	WriteSecretToReference xpv1.SecretReference `json:"writeSecretToRef"`
+}
+
+// DODatabaseClusterForkParameters define the Database Cluster a new cluster is forked from.
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/databases_create_cluster
+type DODatabaseClusterForkParameters struct {
+	// SourceClusterName: The name of the Database Cluster to fork.
+	SourceClusterName string `json:"sourceClusterName"`
+
+	// BackupCreatedAt: The ISO8601 combined date and time of the backup to restore. The most recent backup
+	// is restored if unset (Optional).
+	// +optional
+	BackupCreatedAt *string `json:"backupCreatedAt,omitempty"`
+}
+
+// DODatabaseClusterConfig is the advanced configuration of a Database Cluster.
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/databases_patch_config
+type DODatabaseClusterConfig struct {
+	// PostgreSQL: Configuration of a "pg" cluster (Optional).
+	// +optional
+	PostgreSQL *DODatabaseClusterPostgreSQLConfig `json:"postgresql,omitempty"`
+
+	// Redis: Configuration of a "redis" cluster (Optional).
+	// +optional
+	Redis *DODatabaseClusterRedisConfig `json:"redis,omitempty"`
+}
+
+// DODatabaseClusterPostgreSQLConfig is a subset of the advanced configuration
+// of a PostgreSQL Database Cluster. DigitalOcean derives max_connections from
+// the cluster size, so it can't be configured.
+type DODatabaseClusterPostgreSQLConfig struct {
+	// WorkMem: The maximum amount of memory, in MB, used by a query operation before writing to temporary disk files.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1024
+	WorkMem *int `json:"workMem,omitempty"`
+
+	// SharedBuffersPercentage: The percentage of total RAM that the database server uses for shared memory buffers.
+	// The value must be between 20 and 60.
+	// +optional
+	// +kubebuilder:validation:Minimum=20
+	// +kubebuilder:validation:Maximum=60
+	SharedBuffersPercentage *float32 `json:"sharedBuffersPercentage,omitempty"`
+
+	// Timezone: The PostgreSQL server time zone, e.g. "Europe/Helsinki". It must be the name of a zone in
+	// the IANA time zone database.
+	// +optional
+	Timezone *string `json:"timezone,omitempty"`
+
+	// IdleInTransactionSessionTimeout: Time out sessions that have been idle inside a transaction for longer than the
+	// given number of milliseconds. 0 disables the timeout.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	IdleInTransactionSessionTimeout *int `json:"idleInTransactionSessionTimeout,omitempty"`
+
+	// LogMinDurationStatement: Log statements that take at least the given number of milliseconds. -1 disables logging.
+	// +optional
+	// +kubebuilder:validation:Minimum=-1
+	LogMinDurationStatement *int `json:"logMinDurationStatement,omitempty"`
+
+	// TempFileLimit: The maximum amount of temporary file space, in kB, a process may use. -1 means unlimited.
+	// +optional
+	// +kubebuilder:validation:Minimum=-1
+	TempFileLimit *int `json:"tempFileLimit,omitempty"`
+}
+
+// DODatabaseClusterRedisConfig is a subset of the advanced configuration of a
+// Redis Database Cluster.
+type DODatabaseClusterRedisConfig struct {
+	// Persistence: When "rdb" the data is periodically persisted to disk as snapshots, when "off" it is lost
+	// on restart.
+	// +optional
+	// +kubebuilder:validation:Enum=off;rdb
+	Persistence *string `json:"persistence,omitempty"`
+
+	// Timeout: Close idle client connections after the given number of seconds. 0 disables the timeout.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Timeout *int `json:"timeout,omitempty"`
+
+	// MaxmemoryPolicy: How keys are evicted once the memory limit is reached.
+	// +optional
+	// +kubebuilder:validation:Enum=noeviction;allkeys-lru;allkeys-random;volatile-lru;volatile-random;volatile-ttl
+	MaxmemoryPolicy *string `json:"maxmemoryPolicy,omitempty"`
+
+	// NotifyKeyspaceEvents: The keyspace events that are published to clients, e.g. "Ex". An empty string
+	// disables notifications.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[KEg\$lshzxeA]*$`
+	NotifyKeyspaceEvents *string `json:"notifyKeyspaceEvents,omitempty"`
+}
+
+// DODatabaseClusterOnlineMigrationParameters defines the external database an
+// online migration copies data from.
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/databases_update_onlineMigration
+type DODatabaseClusterOnlineMigrationParameters struct {
+	// Source: The external database to migrate data from.
+	Source DODatabaseClusterOnlineMigrationSource `json:"source"`
+
+	// DisableSSL: Disables SSL encryption when connecting to the source database (Optional).
+	// +optional
+	DisableSSL *bool `json:"disableSSL,omitempty"`
+
+	// IgnoreDBs: A list of databases that should be ignored during migration (Optional).
+	// +optional
+	IgnoreDBs []string `json:"ignoreDBs,omitempty"`
+}
+
+// DODatabaseClusterOnlineMigrationSource defines the connection details of the
+// source database of an online migration.
+type DODatabaseClusterOnlineMigrationSource struct {
+	// Host: The FQDN pointing to the source database's primary node.
+	Host string `json:"host"`
+
+	// Port: The port on which the source database is listening.
+	Port int `json:"port"`
+
+	// DBName: The name of the default database (Optional).
+	// +optional
+	DBName *string `json:"dbName,omitempty"`
+
+	// Username: The user used to connect to the source database.
+	Username string `json:"username"`
+
+	// PasswordSecretRef: A reference to the key of a Secret that holds the password of the user.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// DODatabaseClusterConnectionParameters configures the connection details of a
+// Database Cluster that are written to its connection secret.
+type DODatabaseClusterConnectionParameters struct {
+	// Endpoint: Which of the cluster's endpoints is written to the connection secret. "public" uses the
+	// public hostname, "private" uses the hostname that is only reachable from within the cluster's VPC.
+	// +kubebuilder:validation:Enum="public";"private"
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// SSLMode: The SSL mode of the connection URI. "disable" connects without SSL, "require" requires
+	// SSL without verifying the server certificate, and "verify-full" requires the server certificate to
+	// be verified against the cluster's CA, in which case the CA certificate is written to the connection
+	// secret under the "ca.crt" key. Only applies to PostgreSQL and MySQL clusters. Defaults to
+	// "require" (Optional).
+	// +kubebuilder:validation:Enum="disable";"require";"verify-full"
+	// +optional
+	SSLMode *string `json:"sslMode,omitempty"`
+
+	// Database: The logical database the connection URI targets instead of the cluster's default
+	// database. The database is also written to the connection secret under the "database" key. Only
+	// supported for PostgreSQL and MySQL clusters, and the database must already exist (Optional).
+	// +optional
+	Database *string `json:"database,omitempty"`
+
+	// OmitKeys: Keys that are not written to the connection secret, e.g. "password". All keys are written
+	// by default. Note that the "endpoint" key holds a connection URI that includes the password, so it
+	// must be omitted as well to keep the password out of the secret. Keys that were already written to
+	// the secret are not removed from it (Optional).
+	// +optional
+	OmitKeys []string `json:"omitKeys,omitempty"`
+
+	// Templates: Additional connection strings that are rendered from the observed connection details and
+	// written to the connection secret, e.g. to provide the JDBC or SQLAlchemy URI a client expects (Optional).
+	// +optional
+	Templates []DODatabaseClusterConnectionTemplate `json:"templates,omitempty"`
+}
+
+// A DODatabaseClusterConnectionTemplate renders a connection string into a key of the connection secret.
+type DODatabaseClusterConnectionTemplate struct {
+	// Key: The connection secret key the rendered connection string is written to.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Key string `json:"key"`
+
+	// Template: The connection string to render. The placeholders {host}, {port}, {user}, {password} and
+	// {database} are replaced with the observed connection details as they are, without escaping. No
+	// other braces are allowed, e.g. "jdbc:postgresql://{host}:{port}/{database}?sslmode=require".
+	// +kubebuilder:validation:Pattern=`^([^{}]|\{(host|port|user|password|database)\})*$`
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template"`
 }
 
 // A DODatabaseClusterObservation reflects the observed state of a Database Cluster on DigitalOcean.
@@ -120,6 +396,54 @@ type DODatabaseClusterObservation struct {
 
 	// +kubebuilder:validation:Optional
 	MaintenanceWindow DODatabaseClusterMaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	OnlineMigration DODatabaseClusterOnlineMigrationObservation `json:"onlineMigration,omitempty"`
+
+	// The name of the Database Cluster this cluster was forked from.
+	ForkedFrom string `json:"forkedFrom,omitempty"`
+
+	// PublicAccess: Whether the trusted sources of the cluster lack the one for the IP range of its VPC.
+	// Only observed if spec.forProvider.publicAccess is set.
+	// +optional
+	PublicAccess *bool `json:"publicAccess,omitempty"`
+
+	// TrustedDropletTags: The tags of the Droplets the trusted sources of the cluster allow to connect,
+	// sorted and without duplicates. Only observed while trusted sources of type tag are managed.
+	// +optional
+	TrustedDropletTags []string `json:"trustedDropletTags,omitempty"`
+
+	// Timezone: The PostgreSQL server time zone. Only observed if spec.forProvider.config.postgresql is set.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// CrossRegionReplica: Whether the cluster has a read-only replica in another region that it could
+	// fail over to. Only observed once the cluster is online.
+	// +optional
+	CrossRegionReplica bool `json:"crossRegionReplica,omitempty"`
+
+	// ReplicaRegions: The regions of the read-only replicas of the cluster, sorted and without
+	// duplicates. At most 10 regions are listed.
+	// +optional
+	ReplicaRegions []string `json:"replicaRegions,omitempty"`
+
+	// ObservedParameters: The parameters of the cluster as observed on DigitalOcean, in the shape of
+	// spec.forProvider so that the two can be compared. Parameters DigitalOcean doesn't report, such as
+	// fork, are left unset. Only rendered if the provider is configured to do so.
+	// +optional
+	ObservedParameters *DODatabaseClusterParameters `json:"observedParameters,omitempty"`
+}
+
+// A DODatabaseClusterOnlineMigrationObservation reflects the observed state of an online migration.
+type DODatabaseClusterOnlineMigrationObservation struct {
+	// The ID of the most recent online migration.
+	ID string `json:"id,omitempty"`
+
+	// The current status of the migration. The possible values are: "syncing", "done", "canceled" and "error".
+	Status string `json:"status,omitempty"`
+
+	// A time value given in ISO8601 combined date and time format that represents when the migration was started.
+	CreatedAt string `json:"createdAt,omitempty"`
 }
 
 // A DODatabaseClusterConnection defines the connection information for a Database Cluster.
@@ -160,6 +484,18 @@ type DODatabaseClusterUser struct {
 	MySQLSettings DODatabaseUserMySQLSettings `json:"mySQLSettings,omitempty"`
 }
 
+// DODatabaseClusterUserParameters defines a user of a Database Cluster.
+type DODatabaseClusterUserParameters struct {
+	// Name: The name of the user.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// MySQLSettings: The MySQL settings the user is created with. Changing them doesn't affect a user
+	// that already exists. Only supported for the "mysql" engine (Optional).
+	// +optional
+	MySQLSettings *DODatabaseUserMySQLSettings `json:"mySQLSettings,omitempty"`
+}
+
 // DODatabaseUserMySQLSettings Represents the MySQL Settings of a user for a DigitalOcean Database Cluster
 type DODatabaseUserMySQLSettings struct {
 	// A string specifying the authentication method to be used for connections to the MySQL user account.
@@ -193,6 +529,22 @@ type DODatabaseClusterSpec struct {
 type DODatabaseClusterStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          DODatabaseClusterObservation `json:"atProvider,omitempty"`
+
+	// ConnectionChecksum is the SHA-256 checksum of the connection secret,
+	// as of the last time connection details were written to it. Tooling
+	// can watch it to learn that the connection details changed.
+	// +optional
+	ConnectionChecksum string `json:"connectionChecksum,omitempty"`
+}
+
+// GetConnectionChecksum of this DODatabaseCluster.
+func (mg *DODatabaseCluster) GetConnectionChecksum() string {
+	return mg.Status.ConnectionChecksum
+}
+
+// SetConnectionChecksum of this DODatabaseCluster.
+func (mg *DODatabaseCluster) SetConnectionChecksum(sum string) {
+	mg.Status.ConnectionChecksum = sum
 }
 
 // +kubebuilder:object:root=true
@@ -200,6 +552,7 @@ type DODatabaseClusterStatus struct {
 // A DODatabaseCluster is a managed resource that represents a DigitalOcean Database Cluster.
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="VPC",type="string",JSONPath=".status.atProvider.privateNetworkUUID"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
 type DODatabaseCluster struct {
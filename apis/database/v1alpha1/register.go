@@ -42,6 +42,15 @@ var (
 	DBGroupVersionKind = SchemeGroupVersion.WithKind(DBKind)
 )
 
+// DODatabaseLogsink type metadata.
+var (
+	DODatabaseLogsinkKind             = reflect.TypeOf(DODatabaseLogsink{}).Name()
+	DODatabaseLogsinkGroupKind        = schema.GroupKind{Group: Group, Kind: DODatabaseLogsinkKind}.String()
+	DODatabaseLogsinkKindAPIVersion   = DODatabaseLogsinkKind + "." + SchemeGroupVersion.String()
+	DODatabaseLogsinkGroupVersionKind = SchemeGroupVersion.WithKind(DODatabaseLogsinkKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&DODatabaseCluster{}, &DODatabaseClusterList{})
+	SchemeBuilder.Register(&DODatabaseLogsink{}, &DODatabaseLogsinkList{})
 }
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +53,31 @@ func (in *DODatabaseCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterConfig) DeepCopyInto(out *DODatabaseClusterConfig) {
+	*out = *in
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(DODatabaseClusterPostgreSQLConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(DODatabaseClusterRedisConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterConfig.
+func (in *DODatabaseClusterConfig) DeepCopy() *DODatabaseClusterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterConnection) DeepCopyInto(out *DODatabaseClusterConnection) {
 	*out = *in
@@ -102,6 +128,81 @@ func (in *DODatabaseClusterConnection) DeepCopy() *DODatabaseClusterConnection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterConnectionParameters) DeepCopyInto(out *DODatabaseClusterConnectionParameters) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSLMode != nil {
+		in, out := &in.SSLMode, &out.SSLMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	if in.OmitKeys != nil {
+		in, out := &in.OmitKeys, &out.OmitKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]DODatabaseClusterConnectionTemplate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterConnectionParameters.
+func (in *DODatabaseClusterConnectionParameters) DeepCopy() *DODatabaseClusterConnectionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterConnectionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterConnectionTemplate) DeepCopyInto(out *DODatabaseClusterConnectionTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterConnectionTemplate.
+func (in *DODatabaseClusterConnectionTemplate) DeepCopy() *DODatabaseClusterConnectionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterConnectionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterForkParameters) DeepCopyInto(out *DODatabaseClusterForkParameters) {
+	*out = *in
+	if in.BackupCreatedAt != nil {
+		in, out := &in.BackupCreatedAt, &out.BackupCreatedAt
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterForkParameters.
+func (in *DODatabaseClusterForkParameters) DeepCopy() *DODatabaseClusterForkParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterForkParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterList) DeepCopyInto(out *DODatabaseClusterList) {
 	*out = *in
@@ -154,6 +255,22 @@ func (in *DODatabaseClusterMaintenanceWindow) DeepCopy() *DODatabaseClusterMaint
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterMetricsParameters) DeepCopyInto(out *DODatabaseClusterMetricsParameters) {
+	*out = *in
+	out.WriteSecretToReference = in.WriteSecretToReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterMetricsParameters.
+func (in *DODatabaseClusterMetricsParameters) DeepCopy() *DODatabaseClusterMetricsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterMetricsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterObservation) DeepCopyInto(out *DODatabaseClusterObservation) {
 	*out = *in
@@ -180,6 +297,27 @@ func (in *DODatabaseClusterObservation) DeepCopyInto(out *DODatabaseClusterObser
 		copy(*out, *in)
 	}
 	in.MaintenanceWindow.DeepCopyInto(&out.MaintenanceWindow)
+	out.OnlineMigration = in.OnlineMigration
+	if in.PublicAccess != nil {
+		in, out := &in.PublicAccess, &out.PublicAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrustedDropletTags != nil {
+		in, out := &in.TrustedDropletTags, &out.TrustedDropletTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplicaRegions != nil {
+		in, out := &in.ReplicaRegions, &out.ReplicaRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObservedParameters != nil {
+		in, out := &in.ObservedParameters, &out.ObservedParameters
+		*out = new(DODatabaseClusterParameters)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterObservation.
@@ -192,6 +330,68 @@ func (in *DODatabaseClusterObservation) DeepCopy() *DODatabaseClusterObservation
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterOnlineMigrationObservation) DeepCopyInto(out *DODatabaseClusterOnlineMigrationObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterOnlineMigrationObservation.
+func (in *DODatabaseClusterOnlineMigrationObservation) DeepCopy() *DODatabaseClusterOnlineMigrationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterOnlineMigrationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterOnlineMigrationParameters) DeepCopyInto(out *DODatabaseClusterOnlineMigrationParameters) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.DisableSSL != nil {
+		in, out := &in.DisableSSL, &out.DisableSSL
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreDBs != nil {
+		in, out := &in.IgnoreDBs, &out.IgnoreDBs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterOnlineMigrationParameters.
+func (in *DODatabaseClusterOnlineMigrationParameters) DeepCopy() *DODatabaseClusterOnlineMigrationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterOnlineMigrationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterOnlineMigrationSource) DeepCopyInto(out *DODatabaseClusterOnlineMigrationSource) {
+	*out = *in
+	if in.DBName != nil {
+		in, out := &in.DBName, &out.DBName
+		*out = new(string)
+		**out = **in
+	}
+	out.PasswordSecretRef = in.PasswordSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterOnlineMigrationSource.
+func (in *DODatabaseClusterOnlineMigrationSource) DeepCopy() *DODatabaseClusterOnlineMigrationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterOnlineMigrationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterParameters) DeepCopyInto(out *DODatabaseClusterParameters) {
 	*out = *in
@@ -215,6 +415,58 @@ func (in *DODatabaseClusterParameters) DeepCopyInto(out *DODatabaseClusterParame
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InitialDatabaseName != nil {
+		in, out := &in.InitialDatabaseName, &out.InitialDatabaseName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]DODatabaseClusterUserParameters, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(DODatabaseClusterConnectionParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(DODatabaseClusterMetricsParameters)
+		**out = **in
+	}
+	if in.OnlineMigration != nil {
+		in, out := &in.OnlineMigration, &out.OnlineMigration
+		*out = new(DODatabaseClusterOnlineMigrationParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fork != nil {
+		in, out := &in.Fork, &out.Fork
+		*out = new(DODatabaseClusterForkParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(DODatabaseClusterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublicAccess != nil {
+		in, out := &in.PublicAccess, &out.PublicAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrustedDropletTags != nil {
+		in, out := &in.TrustedDropletTags, &out.TrustedDropletTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterParameters.
@@ -227,6 +479,86 @@ func (in *DODatabaseClusterParameters) DeepCopy() *DODatabaseClusterParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterPostgreSQLConfig) DeepCopyInto(out *DODatabaseClusterPostgreSQLConfig) {
+	*out = *in
+	if in.WorkMem != nil {
+		in, out := &in.WorkMem, &out.WorkMem
+		*out = new(int)
+		**out = **in
+	}
+	if in.SharedBuffersPercentage != nil {
+		in, out := &in.SharedBuffersPercentage, &out.SharedBuffersPercentage
+		*out = new(float32)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.IdleInTransactionSessionTimeout != nil {
+		in, out := &in.IdleInTransactionSessionTimeout, &out.IdleInTransactionSessionTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.LogMinDurationStatement != nil {
+		in, out := &in.LogMinDurationStatement, &out.LogMinDurationStatement
+		*out = new(int)
+		**out = **in
+	}
+	if in.TempFileLimit != nil {
+		in, out := &in.TempFileLimit, &out.TempFileLimit
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterPostgreSQLConfig.
+func (in *DODatabaseClusterPostgreSQLConfig) DeepCopy() *DODatabaseClusterPostgreSQLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterPostgreSQLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterRedisConfig) DeepCopyInto(out *DODatabaseClusterRedisConfig) {
+	*out = *in
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxmemoryPolicy != nil {
+		in, out := &in.MaxmemoryPolicy, &out.MaxmemoryPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NotifyKeyspaceEvents != nil {
+		in, out := &in.NotifyKeyspaceEvents, &out.NotifyKeyspaceEvents
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterRedisConfig.
+func (in *DODatabaseClusterRedisConfig) DeepCopy() *DODatabaseClusterRedisConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterRedisConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterSpec) DeepCopyInto(out *DODatabaseClusterSpec) {
 	*out = *in
@@ -277,6 +609,250 @@ func (in *DODatabaseClusterUser) DeepCopy() *DODatabaseClusterUser {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterUserParameters) DeepCopyInto(out *DODatabaseClusterUserParameters) {
+	*out = *in
+	if in.MySQLSettings != nil {
+		in, out := &in.MySQLSettings, &out.MySQLSettings
+		*out = new(DODatabaseUserMySQLSettings)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterUserParameters.
+func (in *DODatabaseClusterUserParameters) DeepCopy() *DODatabaseClusterUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsink) DeepCopyInto(out *DODatabaseLogsink) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsink.
+func (in *DODatabaseLogsink) DeepCopy() *DODatabaseLogsink {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DODatabaseLogsink) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsinkElasticsearchConfig) DeepCopyInto(out *DODatabaseLogsinkElasticsearchConfig) {
+	*out = *in
+	out.URLSecretRef = in.URLSecretRef
+	if in.IndexDaysMax != nil {
+		in, out := &in.IndexDaysMax, &out.IndexDaysMax
+		*out = new(int)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsinkElasticsearchConfig.
+func (in *DODatabaseLogsinkElasticsearchConfig) DeepCopy() *DODatabaseLogsinkElasticsearchConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsinkElasticsearchConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsinkList) DeepCopyInto(out *DODatabaseLogsinkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DODatabaseLogsink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsinkList.
+func (in *DODatabaseLogsinkList) DeepCopy() *DODatabaseLogsinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsinkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DODatabaseLogsinkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsinkObservation) DeepCopyInto(out *DODatabaseLogsinkObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsinkObservation.
+func (in *DODatabaseLogsinkObservation) DeepCopy() *DODatabaseLogsinkObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsinkObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsinkParameters) DeepCopyInto(out *DODatabaseLogsinkParameters) {
+	*out = *in
+	if in.ClusterID != nil {
+		in, out := &in.ClusterID, &out.ClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterIDRef != nil {
+		in, out := &in.ClusterIDRef, &out.ClusterIDRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ClusterIDSelector != nil {
+		in, out := &in.ClusterIDSelector, &out.ClusterIDSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rsyslog != nil {
+		in, out := &in.Rsyslog, &out.Rsyslog
+		*out = new(DODatabaseLogsinkRsyslogConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(DODatabaseLogsinkElasticsearchConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsinkParameters.
+func (in *DODatabaseLogsinkParameters) DeepCopy() *DODatabaseLogsinkParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsinkParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsinkRsyslogConfig) DeepCopyInto(out *DODatabaseLogsinkRsyslogConfig) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Logline != nil {
+		in, out := &in.Logline, &out.Logline
+		*out = new(string)
+		**out = **in
+	}
+	if in.SD != nil {
+		in, out := &in.SD, &out.SD
+		*out = new(string)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(string)
+		**out = **in
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsinkRsyslogConfig.
+func (in *DODatabaseLogsinkRsyslogConfig) DeepCopy() *DODatabaseLogsinkRsyslogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsinkRsyslogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsinkSpec) DeepCopyInto(out *DODatabaseLogsinkSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsinkSpec.
+func (in *DODatabaseLogsinkSpec) DeepCopy() *DODatabaseLogsinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseLogsinkStatus) DeepCopyInto(out *DODatabaseLogsinkStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseLogsinkStatus.
+func (in *DODatabaseLogsinkStatus) DeepCopy() *DODatabaseLogsinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseLogsinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseUserMySQLSettings) DeepCopyInto(out *DODatabaseUserMySQLSettings) {
 	*out = *in
@@ -74,3 +74,59 @@ func (mg *DODatabaseCluster) SetProviderReference(r *xpv1.Reference) {
 func (mg *DODatabaseCluster) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
 	mg.Spec.WriteConnectionSecretToReference = r
 }
+
+// GetCondition of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetProviderConfigReference of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+/*
+GetProviderReference of this DODatabaseLogsink.
+Deprecated: Use GetProviderConfigReference.
+*/
+func (mg *DODatabaseLogsink) GetProviderReference() *xpv1.Reference {
+	return mg.Spec.ProviderReference
+}
+
+// GetWriteConnectionSecretToReference of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetConditions of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetProviderConfigReference of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+/*
+SetProviderReference of this DODatabaseLogsink.
+Deprecated: Use SetProviderConfigReference.
+*/
+func (mg *DODatabaseLogsink) SetProviderReference(r *xpv1.Reference) {
+	mg.Spec.ProviderReference = r
+}
+
+// SetWriteConnectionSecretToReference of this DODatabaseLogsink.
+func (mg *DODatabaseLogsink) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
@@ -26,6 +26,11 @@ limitations under the License.
 // Generate deepcopy methodsets and CRD manifests
 //go:generate go run -tags generate sigs.k8s.io/controller-tools/cmd/controller-gen object:headerFile=../hack/boilerplate.go.txt paths=./... crd:allowDangerousTypes=true,crdVersions=v1 output:artifacts:config=../package/crds
 
+// Make the namespace of connection secrets optional, so that it can be
+// defaulted by the --connection-secret-namespace flag. The SecretReference
+// type of crossplane-runtime requires it.
+//go:generate sh -c "sed -i '/writeConnectionSecretToRef:/,/type: object/{/^ *- namespace$/d}' ../package/crds/*.yaml"
+
 // Generate crossplane-runtime methodsets (resource.Claim, etc)
 //go:generate go run -tags generate github.com/crossplane/crossplane-tools/cmd/angryjet generate-methodsets --header-file=../hack/boilerplate.go.txt ./...
 
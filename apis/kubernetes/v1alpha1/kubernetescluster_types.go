@@ -75,6 +75,10 @@ type DOKubernetesClusterParameters struct {
 	// A boolean value indicating whether the control plane is run in a highly available configuration in the cluster. Highly available control planes incur less downtime.
 	// +kubebuilder:validation:Optional
 	HighlyAvailable *bool `json:"highlyAvailable,omitempty"`
+
+	// A boolean value indicating whether the cluster is integrated with the account's container registry, allowing its nodes to pull images from it.
+	// +kubebuilder:validation:Optional
+	RegistryIntegration *bool `json:"registryIntegration,omitempty"`
 }
 
 // DOKubernetesClusterObservation reflects the observed state of a KubernetesCluster on DigitalOcean.
@@ -139,6 +143,10 @@ type DOKubernetesClusterObservation struct {
 
 	// A read-only boolean value indicating if a container registry is integrated with the cluster.
 	RegistryEnabled bool `json:"registryEnabled,omitempty"`
+
+	// The IDs of the nodes that were requested to be recycled using the
+	// do.crossplane.io/recycle-nodes annotation and haven't been replaced yet.
+	RecyclingNodes []string `json:"recyclingNodes,omitempty"`
 }
 
 // KubernetesNodePool represents a node pool that makes up a Kubernetes Cluster
@@ -309,6 +317,22 @@ type DOKubernetesClusterSpec struct {
 type DOKubernetesClusterStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          DOKubernetesClusterObservation `json:"atProvider,omitempty"`
+
+	// ConnectionChecksum is the SHA-256 checksum of the connection secret,
+	// as of the last time connection details were written to it. Tooling
+	// can watch it to learn that the connection details changed.
+	// +optional
+	ConnectionChecksum string `json:"connectionChecksum,omitempty"`
+}
+
+// GetConnectionChecksum of this DOKubernetesCluster.
+func (mg *DOKubernetesCluster) GetConnectionChecksum() string {
+	return mg.Status.ConnectionChecksum
+}
+
+// SetConnectionChecksum of this DOKubernetesCluster.
+func (mg *DOKubernetesCluster) SetConnectionChecksum(sum string) {
+	mg.Status.ConnectionChecksum = sum
 }
 
 // +kubebuilder:object:root=true
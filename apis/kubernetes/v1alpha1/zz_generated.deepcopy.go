@@ -230,6 +230,11 @@ func (in *DOKubernetesClusterObservation) DeepCopyInto(out *DOKubernetesClusterO
 	}
 	out.MaintenancePolicy = in.MaintenancePolicy
 	out.Status = in.Status
+	if in.RecyclingNodes != nil {
+		in, out := &in.RecyclingNodes, &out.RecyclingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesClusterObservation.
@@ -282,6 +287,11 @@ func (in *DOKubernetesClusterParameters) DeepCopyInto(out *DOKubernetesClusterPa
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RegistryIntegration != nil {
+		in, out := &in.RegistryIntegration, &out.RegistryIntegration
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesClusterParameters.
@@ -44,6 +44,19 @@ type LBParameters struct {
 	// +kubebuilder:validation:Enum=round_robin;least_connections
 	Algorithm string `json:"algorithm"`
 
+	// Size: The size of the LB, one of "lb-small", "lb-medium" or "lb-large".
+	// Only one of size and sizeUnit may be set. Changing it resizes the LB.
+	// +optional
+	Size *string `json:"size,omitempty"`
+
+	// SizeUnit: The number of nodes the LB is scaled to. Each node can
+	// handle a fixed amount of traffic. Only one of size and sizeUnit may
+	// be set. Changing it scales the LB.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	SizeUnit *int `json:"sizeUnit,omitempty"`
+
 	// API Server port. It must be valid ports range (1-65535). If omitted, default value is 6443.
 	// +optional
 	// +kubebuilder:validation:Minimum=1
@@ -54,12 +67,20 @@ type LBParameters struct {
 	// +optional
 	HealthCheck DOLoadBalancerHealthCheck `json:"healthCheck,omitempty"`
 
-	// Tags: A flat array of tag names as strings to apply to the LB after it
-	// is created. Tag names can either be existing or new tags.
+	// Tags: A flat array of tag names as strings to apply to the LB. Tag
+	// names can either be existing or new tags. Tags that are removed from
+	// this list are removed from the LB, while tags added to the LB outside
+	// of Crossplane are left alone.
 	// +optional
-	// +immutable
 	Tags []string `json:"tags,omitempty"`
 
+	// IgnoreTagsMatching: Tags that are managed outside of the provider,
+	// e.g. by billing automation. Matching tags are never added to or removed
+	// from the LB and don't count as drift. An entry matches a tag exactly,
+	// or, if it ends with "*", any tag starting with it, e.g. "billing:*".
+	// +optional
+	IgnoreTagsMatching []string `json:"ignoreTagsMatching,omitempty"`
+
 	// VPCUUID: A string specifying the UUID of the VPC to which the LB
 	// will be assigned. If excluded, beginning on April 7th, 2020, the LB
 	// will be assigned to your account's default VPC for the region.
@@ -107,6 +128,9 @@ type LBObservation struct {
 	// IP for the resource.
 	IP int `json:"ip,omitempty"`
 
+	// SizeUnit is the number of nodes the LB is currently scaled to.
+	SizeUnit int `json:"sizeUnit,omitempty"`
+
 	// A Status string indicating the state of the LB instance.
 	//
 	// Possible values:
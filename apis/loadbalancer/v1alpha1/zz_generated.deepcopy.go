@@ -117,12 +117,27 @@ func (in *LBObservation) DeepCopy() *LBObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LBParameters) DeepCopyInto(out *LBParameters) {
 	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(string)
+		**out = **in
+	}
+	if in.SizeUnit != nil {
+		in, out := &in.SizeUnit, &out.SizeUnit
+		*out = new(int)
+		**out = **in
+	}
 	out.HealthCheck = in.HealthCheck
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IgnoreTagsMatching != nil {
+		in, out := &in.IgnoreTagsMatching, &out.IgnoreTagsMatching
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.VPCUUID != nil {
 		in, out := &in.VPCUUID, &out.VPCUUID
 		*out = new(string)
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Known Spaces key permissions.
+const (
+	PermissionRead       = "read"
+	PermissionReadWrite  = "readwrite"
+	PermissionFullAccess = "fullaccess"
+)
+
+// DOSpacesKeyParameters define the desired state of a DigitalOcean Spaces
+// access key. The name of the key is the name of the managed resource.
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/spacesKey_create
+type DOSpacesKeyParameters struct {
+	// Grants: The buckets the key can access, and with which permission.
+	// +kubebuilder:validation:MinItems=1
+	Grants []DOSpacesKeyGrant `json:"grants"`
+}
+
+// A DOSpacesKeyGrant grants a Spaces key access to a bucket.
+type DOSpacesKeyGrant struct {
+	// Bucket: The name of the bucket access is granted to. It must be unset
+	// for the "fullaccess" permission, which grants access to all buckets.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+
+	// Permission: The access that is granted, one of "read", "readwrite" or
+	// "fullaccess".
+	// +kubebuilder:validation:Enum=read;readwrite;fullaccess
+	Permission string `json:"permission"`
+}
+
+// A DOSpacesKeyObservation reflects the observed state of a Spaces key on
+// DigitalOcean.
+type DOSpacesKeyObservation struct {
+	// AccessKeyID: The ID of the access key.
+	AccessKeyID string `json:"accessKeyId,omitempty"`
+
+	// CreatedAt: A time value given in ISO8601 combined date and time format
+	// that represents when the key was created.
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// A DOSpacesKeySpec defines the desired state of a Spaces key.
+type DOSpacesKeySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DOSpacesKeyParameters `json:"forProvider"`
+}
+
+// A DOSpacesKeyStatus represents the observed state of a Spaces key.
+type DOSpacesKeyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DOSpacesKeyObservation `json:"atProvider,omitempty"`
+
+	// ConnectionChecksum is the SHA-256 checksum of the connection secret,
+	// as of the last time connection details were written to it. Tooling
+	// can watch it to learn that the connection details changed.
+	// +optional
+	ConnectionChecksum string `json:"connectionChecksum,omitempty"`
+}
+
+// GetConnectionChecksum of this DOSpacesKey.
+func (mg *DOSpacesKey) GetConnectionChecksum() string {
+	return mg.Status.ConnectionChecksum
+}
+
+// SetConnectionChecksum of this DOSpacesKey.
+func (mg *DOSpacesKey) SetConnectionChecksum(sum string) {
+	mg.Status.ConnectionChecksum = sum
+}
+
+// +kubebuilder:object:root=true
+
+// A DOSpacesKey is a managed resource that represents a DigitalOcean Spaces
+// access key. The access key ID and secret are written to the connection
+// secret when the key is created, which is the only time DigitalOcean
+// reveals the secret.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ACCESS-KEY",type="string",JSONPath=".status.atProvider.accessKeyId"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type DOSpacesKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOSpacesKeySpec   `json:"spec"`
+	Status DOSpacesKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DOSpacesKeyList contains a list of Spaces keys.
+type DOSpacesKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOSpacesKey `json:"items"`
+}
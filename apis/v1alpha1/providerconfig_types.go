@@ -27,14 +27,50 @@ type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
 
-	// Add any other fields here for information that is specific to configuring
-	// a provider, such as authentication details.
+	// APITimeoutSeconds is the time a request to the DigitalOcean API may
+	// take, including any retries, before it is canceled. Requests don't
+	// time out if it is unset or 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	APITimeoutSeconds *int `json:"apiTimeoutSeconds,omitempty"`
+
+	// APIMaxRetries is the number of times a request to the DigitalOcean API
+	// is retried if it fails with a network error, is rate limited or fails
+	// on the server side. Requests that create resources are only retried if
+	// they were rate limited or the API was unavailable, so that a resource is
+	// never created twice. Requests aren't retried if it is unset or 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	APIMaxRetries *int `json:"apiMaxRetries,omitempty"`
+
+	// ProxyURL is the URL of the HTTP or HTTPS proxy that requests to the
+	// DigitalOcean API are sent through. The HTTPS_PROXY and NO_PROXY
+	// environment variables of the provider are honored if it is unset.
+	// +optional
+	ProxyURL *string `json:"proxyURL,omitempty"`
+
+	// CACertSecretRef references a secret key that contains PEM encoded CA
+	// certificates to trust in addition to the system ones when calling the
+	// DigitalOcean API, e.g. the CA of a TLS-inspecting proxy.
+	// +optional
+	CACertSecretRef *xpv1.SecretKeySelector `json:"caCertSecretRef,omitempty"`
+
+	// AllowedRegions are the slugs of the regions resources using this
+	// ProviderConfig may be created in, e.g. to prevent accidental spending
+	// in unapproved regions. Creating a resource in any other region fails.
+	// All regions are allowed if it is empty.
+	// +optional
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
-	// Source of the provider credentials.
+	// Source of the provider credentials. The DigitalOcean token is read
+	// from the key of a secret by default, from the file at a path if the
+	// source is Filesystem, or from an environment variable of the provider,
+	// e.g. DIGITALOCEAN_TOKEN, if the source is Environment.
 	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
+	// +kubebuilder:default=Secret
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
@@ -28,6 +28,7 @@ import (
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
 )
 
 func main() {
@@ -36,6 +37,13 @@ func main() {
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod     = app.Flag("sync", "Controller manager sync period duration such as 300ms, 1.5h or 2h45m").Short('s').Default("1h").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		finalizer      = app.Flag("finalizer", "Finalizer added to managed resources. Provider installations sharing a cluster must use different finalizers.").Default(options.DefaultFinalizer).String()
+		readOnlySpec   = app.Flag("read-only-spec", "Don't write late-initialized values back to the spec of managed resources; report them in their status instead.").Default("false").Bool()
+		opTimeout      = app.Flag("operation-timeout", "Timeout for each call that creates or updates an external resource, such as 5m. Zero disables it.").Default("0s").Duration()
+		secretNS       = app.Flag("connection-secret-namespace", "Namespace connection secrets are written to when a managed resource doesn't specify one.").String()
+		skipChecks     = app.Flag("skip-availability-checks", "Don't check that the requested size is available in the requested region before creating a resource.").Default("false").Bool()
+		renderObserved = app.Flag("render-observed-parameters", "Render the parameters of external resources as observed on DigitalOcean into the status of managed resources that support it.").Default("false").Bool()
+		observeOnly    = app.Flag("observe-only", "Only observe external resources and report their drift; never create, update or delete them.").Default("false").Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -61,6 +69,6 @@ func main() {
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add DigitalOcean APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup DigitalOcean controllers")
+	kingpin.FatalIfError(controller.Setup(mgr, log, options.Options{Finalizer: *finalizer, ReadOnlySpec: *readOnlySpec, OperationTimeout: *opTimeout, ConnectionSecretNamespace: *secretNS, SkipAvailabilityChecks: *skipChecks, RenderObservedParameters: *renderObserved, ObserveOnly: *observeOnly}), "Cannot setup DigitalOcean controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+)
+
+const (
+	destroyAssociatedPath       = "/v2/droplets/%d/destroy_with_associated_resources/dangerous"
+	destroyAssociatedStatusPath = "/v2/droplets/%d/destroy_with_associated_resources/status"
+
+	// headerDangerous must be set to confirm that associated resources are
+	// destroyed along with a Droplet.
+	headerDangerous = "X-Dangerous"
+)
+
+// DestroyStatus is the status of destroying a Droplet along with its
+// associated resources.
+type DestroyStatus struct {
+	CompletedAt string `json:"completed_at,omitempty"`
+	Failures    int    `json:"failures"`
+}
+
+// Completed returns true if DigitalOcean finished destroying the Droplet and
+// its associated resources, whether or not all of them were destroyed.
+func (s *DestroyStatus) Completed() bool {
+	return s.CompletedAt != ""
+}
+
+// AssociatedResourcesClient is the external client used to destroy a Droplet
+// along with its associated resources. godo does not support these endpoints
+// yet.
+type AssociatedResourcesClient interface {
+	DestroyWithAssociatedResources(context.Context, int) (*godo.Response, error)
+	GetDestroyStatus(context.Context, int) (*DestroyStatus, *godo.Response, error)
+}
+
+// NewAssociatedResourcesClient returns an AssociatedResourcesClient that
+// issues requests through the supplied godo.Client.
+func NewAssociatedResourcesClient(c *godo.Client) AssociatedResourcesClient {
+	return &associatedResourcesClient{client: c}
+}
+
+type associatedResourcesClient struct {
+	client *godo.Client
+}
+
+func (c *associatedResourcesClient) DestroyWithAssociatedResources(ctx context.Context, id int) (*godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodDelete, fmt.Sprintf(destroyAssociatedPath, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(headerDangerous, "true")
+	return c.client.Do(ctx, req, nil)
+}
+
+func (c *associatedResourcesClient) GetDestroyStatus(ctx context.Context, id int) (*DestroyStatus, *godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf(destroyAssociatedStatusPath, id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	status := new(DestroyStatus)
+	resp, err := c.client.Do(ctx, req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+	return status, resp, nil
+}
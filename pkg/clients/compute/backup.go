@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	backupPolicyPath  = "/v2/droplets/%d/backups/policy"
+	dropletActionPath = "/v2/droplets/%d/actions"
+
+	// BackupPlanDaily takes a backup every day.
+	BackupPlanDaily = "daily"
+	// BackupPlanWeekly takes a backup once a week.
+	BackupPlanWeekly = "weekly"
+
+	errBackupsDisabled   = "backupPolicy requires backups to be enabled"
+	errUnsupportedPlan   = "unsupported backup plan %q, must be one of: daily, weekly"
+	errUnsupportedHour   = "unsupported backup hour %d, must be one of: 0, 4, 8, 12, 16, 20"
+	errUnsupportedDay    = "unsupported backup weekday %q, must be one of: SUN, MON, TUE, WED, THU, FRI, SAT"
+	errWeekdayNotAllowed = "backup weekday can only be set for the weekly plan"
+)
+
+var weekdays = map[string]bool{"SUN": true, "MON": true, "TUE": true, "WED": true, "THU": true, "FRI": true, "SAT": true}
+
+// BackupPolicy is the backup policy of a Droplet.
+type BackupPolicy struct {
+	Plan    string `json:"plan,omitempty"`
+	Weekday string `json:"weekday,omitempty"`
+	Hour    *int   `json:"hour,omitempty"`
+}
+
+// DropletBackupPolicy is the observed backup policy of a Droplet.
+type DropletBackupPolicy struct {
+	DropletID     int           `json:"droplet_id"`
+	BackupEnabled bool          `json:"backup_enabled"`
+	BackupPolicy  *BackupPolicy `json:"backup_policy,omitempty"`
+}
+
+// BackupPolicyClient is the external client used to manage the backup policy
+// of a Droplet. godo does not support these endpoints yet.
+type BackupPolicyClient interface {
+	GetBackupPolicy(context.Context, int) (*DropletBackupPolicy, *godo.Response, error)
+	ChangeBackupPolicy(context.Context, int, *BackupPolicy) (*godo.Action, *godo.Response, error)
+}
+
+// NewBackupPolicyClient returns a BackupPolicyClient that issues requests
+// through the supplied godo.Client.
+func NewBackupPolicyClient(c *godo.Client) BackupPolicyClient {
+	return &backupPolicyClient{client: c}
+}
+
+type backupPolicyClient struct {
+	client *godo.Client
+}
+
+func (c *backupPolicyClient) GetBackupPolicy(ctx context.Context, id int) (*DropletBackupPolicy, *godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf(backupPolicyPath, id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := new(struct {
+		Policy *DropletBackupPolicy `json:"policy"`
+	})
+	resp, err := c.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Policy, resp, nil
+}
+
+func (c *backupPolicyClient) ChangeBackupPolicy(ctx context.Context, id int, p *BackupPolicy) (*godo.Action, *godo.Response, error) {
+	body := map[string]interface{}{"type": "change_backup_policy", "backup_policy": p}
+	req, err := c.client.NewRequest(ctx, http.MethodPost, fmt.Sprintf(dropletActionPath, id), body)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := new(struct {
+		Action *godo.Action `json:"action"`
+	})
+	resp, err := c.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Action, resp, nil
+}
+
+// ValidateBackupPolicy returns an error if the backup policy of the supplied
+// DropletParameters can't be applied by DigitalOcean.
+func ValidateBackupPolicy(p v1alpha1.DropletParameters) error {
+	bp := p.BackupPolicy
+	if bp == nil {
+		return nil
+	}
+	if !do.BoolValue(p.Backups) {
+		return errors.New(errBackupsDisabled)
+	}
+	if bp.Plan != BackupPlanDaily && bp.Plan != BackupPlanWeekly {
+		return errors.Errorf(errUnsupportedPlan, bp.Plan)
+	}
+	if bp.Hour != nil && (*bp.Hour < 0 || *bp.Hour > 20 || *bp.Hour%4 != 0) {
+		return errors.Errorf(errUnsupportedHour, *bp.Hour)
+	}
+	if bp.Weekday != nil {
+		if bp.Plan != BackupPlanWeekly {
+			return errors.New(errWeekdayNotAllowed)
+		}
+		if !weekdays[*bp.Weekday] {
+			return errors.Errorf(errUnsupportedDay, *bp.Weekday)
+		}
+	}
+	return nil
+}
+
+// GenerateBackupPolicy generates *BackupPolicy instance from
+// DropletBackupPolicy.
+func GenerateBackupPolicy(in v1alpha1.DropletBackupPolicy) *BackupPolicy {
+	return &BackupPolicy{
+		Plan:    in.Plan,
+		Weekday: do.StringValue(in.Weekday),
+		Hour:    in.Hour,
+	}
+}
+
+// IsBackupPolicyUpToDate returns true if the supplied DropletBackupPolicy
+// matches the observed backup policy. Fields that are not set are not
+// compared, as DigitalOcean picks them.
+func IsBackupPolicyUpToDate(in v1alpha1.DropletBackupPolicy, observed *BackupPolicy) bool {
+	if observed == nil {
+		return false
+	}
+	if in.Plan != observed.Plan {
+		return false
+	}
+	if in.Weekday != nil && *in.Weekday != observed.Weekday {
+		return false
+	}
+	return in.Hour == nil || (observed.Hour != nil && *in.Hour == *observed.Hour)
+}
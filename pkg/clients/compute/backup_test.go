@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+)
+
+func TestValidateBackupPolicy(t *testing.T) {
+	withPolicy := func(bp v1alpha1.DropletBackupPolicy) v1alpha1.DropletParameters {
+		return v1alpha1.DropletParameters{Backups: godo.PtrTo(true), BackupPolicy: &bp}
+	}
+
+	cases := map[string]struct {
+		p    v1alpha1.DropletParameters
+		want error
+	}{
+		"NoPolicy": {},
+		"Daily": {
+			p: withPolicy(v1alpha1.DropletBackupPolicy{Plan: BackupPlanDaily, Hour: godo.PtrTo(8)}),
+		},
+		"Weekly": {
+			p: withPolicy(v1alpha1.DropletBackupPolicy{Plan: BackupPlanWeekly, Weekday: godo.PtrTo("SUN"), Hour: godo.PtrTo(20)}),
+		},
+		"BackupsDisabled": {
+			p:    v1alpha1.DropletParameters{BackupPolicy: &v1alpha1.DropletBackupPolicy{Plan: BackupPlanDaily}},
+			want: errors.New(errBackupsDisabled),
+		},
+		"UnsupportedPlan": {
+			p:    withPolicy(v1alpha1.DropletBackupPolicy{Plan: "monthly"}),
+			want: errors.Errorf(errUnsupportedPlan, "monthly"),
+		},
+		"HourNotInWindow": {
+			p:    withPolicy(v1alpha1.DropletBackupPolicy{Plan: BackupPlanDaily, Hour: godo.PtrTo(3)}),
+			want: errors.Errorf(errUnsupportedHour, 3),
+		},
+		"HourOutOfRange": {
+			p:    withPolicy(v1alpha1.DropletBackupPolicy{Plan: BackupPlanDaily, Hour: godo.PtrTo(24)}),
+			want: errors.Errorf(errUnsupportedHour, 24),
+		},
+		"WeekdayOnDailyPlan": {
+			p:    withPolicy(v1alpha1.DropletBackupPolicy{Plan: BackupPlanDaily, Weekday: godo.PtrTo("MON")}),
+			want: errors.New(errWeekdayNotAllowed),
+		},
+		"UnsupportedWeekday": {
+			p:    withPolicy(v1alpha1.DropletBackupPolicy{Plan: BackupPlanWeekly, Weekday: godo.PtrTo("Sunday")}),
+			want: errors.Errorf(errUnsupportedDay, "Sunday"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ValidateBackupPolicy(tc.p), test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateBackupPolicy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsBackupPolicyUpToDate(t *testing.T) {
+	observed := &BackupPolicy{Plan: BackupPlanWeekly, Weekday: "SUN", Hour: godo.PtrTo(0)}
+
+	cases := map[string]struct {
+		in       v1alpha1.DropletBackupPolicy
+		observed *BackupPolicy
+		want     bool
+	}{
+		"Matches": {
+			in:       v1alpha1.DropletBackupPolicy{Plan: BackupPlanWeekly, Weekday: godo.PtrTo("SUN"), Hour: godo.PtrTo(0)},
+			observed: observed,
+			want:     true,
+		},
+		"OnlyPlanSpecified": {
+			in:       v1alpha1.DropletBackupPolicy{Plan: BackupPlanWeekly},
+			observed: observed,
+			want:     true,
+		},
+		"PlanDrifted": {
+			in:       v1alpha1.DropletBackupPolicy{Plan: BackupPlanDaily},
+			observed: observed,
+		},
+		"HourDrifted": {
+			in:       v1alpha1.DropletBackupPolicy{Plan: BackupPlanWeekly, Hour: godo.PtrTo(12)},
+			observed: observed,
+		},
+		"NotObserved": {
+			in: v1alpha1.DropletBackupPolicy{Plan: BackupPlanWeekly},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsBackupPolicyUpToDate(tc.in, tc.observed); got != tc.want {
+				t.Errorf("IsBackupPolicyUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -17,14 +17,136 @@ limitations under the License.
 package compute
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+// DropletLister lists Droplets by tag.
+type DropletLister interface {
+	ListByTag(context.Context, string, *godo.ListOptions) ([]godo.Droplet, *godo.Response, error)
+}
+
+// ListByTag returns every Droplet that has the supplied tag. Droplets are
+// filtered by the DigitalOcean API, so only matching Droplets are listed.
+func ListByTag(ctx context.Context, c DropletLister, tag string) ([]godo.Droplet, error) {
+	var droplets []godo.Droplet
+	opt := &godo.ListOptions{PerPage: do.MaxListPerPage}
+	for {
+		page, resp, err := c.ListByTag(ctx, tag, opt)
+		if err != nil {
+			return nil, err
+		}
+		droplets = append(droplets, page...)
+		more, err := do.NextPage(resp, opt)
+		if err != nil || !more {
+			return droplets, err
+		}
+	}
+}
+
+// oneClickTypeDroplet is the type of the 1-Click applications that can be
+// deployed to a Droplet.
+const oneClickTypeDroplet = "droplet"
+
+const errUnknownOneClickApp = "unknown 1-Click application %q"
+
+// OneClickLister lists 1-Click applications by type.
+type OneClickLister interface {
+	List(context.Context, string) ([]*godo.OneClick, *godo.Response, error)
+}
+
+// ValidateOneClickApp returns an error if the supplied slug isn't one of the
+// 1-Click applications that can be deployed to a Droplet.
+func ValidateOneClickApp(ctx context.Context, c OneClickLister, slug string) error {
+	apps, _, err := c.List(ctx, oneClickTypeDroplet)
+	if err != nil {
+		return err
+	}
+	for _, app := range apps {
+		if app != nil && app.Slug == slug {
+			return nil
+		}
+	}
+	return errors.Errorf(errUnknownOneClickApp, slug)
+}
+
+const (
+	errGetVPC            = "cannot get the VPC of the Droplet"
+	errVPCRegionMismatch = "the Droplet's region %q does not match the region %q of its VPC %q"
+)
+
+// VPCGetter gets VPCs.
+type VPCGetter interface {
+	Get(context.Context, string) (*godo.VPC, *godo.Response, error)
+}
+
+// ValidateVPCRegion returns an error if the supplied DropletParameters place
+// the Droplet in a VPC of another region. DigitalOcean would reject such a
+// Droplet, but with a less helpful error.
+func ValidateVPCRegion(ctx context.Context, c VPCGetter, p v1alpha1.DropletParameters) error {
+	id := do.StringValue(p.VPCUUID)
+	if id == "" {
+		return nil
+	}
+	vpc, _, err := c.Get(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, errGetVPC)
+	}
+	if vpc.RegionSlug != p.Region {
+		return errors.Errorf(errVPCRegionMismatch, p.Region, vpc.RegionSlug, id)
+	}
+	return nil
+}
+
+const (
+	errListSizes       = "cannot list the Droplet sizes"
+	errSizeUnavailable = "the Droplet size %q is not available in region %q"
+)
+
+// SizeLister lists Droplet sizes.
+type SizeLister interface {
+	List(context.Context, *godo.ListOptions) ([]godo.Size, *godo.Response, error)
+}
+
+// ValidateSizeRegion returns an error if the size of the supplied
+// DropletParameters is unknown or not available in their region.
+func ValidateSizeRegion(ctx context.Context, c SizeLister, p v1alpha1.DropletParameters) error {
+	opt := &godo.ListOptions{PerPage: do.MaxListPerPage}
+	for {
+		sizes, resp, err := c.List(ctx, opt)
+		if err != nil {
+			return errors.Wrap(err, errListSizes)
+		}
+		for _, s := range sizes {
+			if s.Slug != p.Size {
+				continue
+			}
+			for _, r := range s.Regions {
+				if s.Available && r == p.Region {
+					return nil
+				}
+			}
+			return errors.Errorf(errSizeUnavailable, p.Size, p.Region)
+		}
+		more, err := do.NextPage(resp, opt)
+		if err != nil {
+			return errors.Wrap(err, errListSizes)
+		}
+		if !more {
+			return errors.Errorf(errSizeUnavailable, p.Size, p.Region)
+		}
+	}
+}
+
 // GenerateDroplet generates *godo.DropletCreateRequest instance from DropletParameters.
 func GenerateDroplet(name string, in v1alpha1.DropletParameters, create *godo.DropletCreateRequest) {
 	create.Name = name
@@ -38,7 +160,7 @@ func GenerateDroplet(name string, in v1alpha1.DropletParameters, create *godo.Dr
 	create.Monitoring = do.BoolValue(in.Monitoring)
 	create.UserData = do.StringValue(in.UserData)
 	create.Volumes = generateVolumes(in.Volumes)
-	create.Tags = in.Tags
+	create.Tags = do.FilterIgnoredTags(in.Tags, in.IgnoreTagsMatching)
 	create.VPCUUID = do.StringValue(in.VPCUUID)
 	create.WithDropletAgent = in.WithDropletAgent
 }
@@ -76,11 +198,71 @@ func generateVolumes(param []string) []godo.DropletCreateVolume {
 	return volumes
 }
 
+// RootUser is the user the SSH keys of a Droplet are embedded for.
+const RootUser = "root"
+
+// Connection secret keys of a Droplet.
+const (
+	ConnectionSecretPublicIPv4Key  = "publicIPv4"
+	ConnectionSecretPrivateIPv4Key = "privateIPv4"
+)
+
+// GenerateConnectionDetails returns the connection details of the supplied
+// Droplet. The endpoint is the public IPv4 address of the Droplet, or its
+// private IPv4 address if it has no public one, and is reached over SSH as
+// RootUser. Addresses the Droplet doesn't have are omitted. The root password
+// DigitalOcean emails for Droplets without SSH keys is never returned by the
+// API, so it is never part of the connection details.
+func GenerateConnectionDetails(observed godo.Droplet) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	public, _ := observed.PublicIPv4()
+	private, _ := observed.PrivateIPv4()
+	if public != "" {
+		cd[ConnectionSecretPublicIPv4Key] = []byte(public)
+	}
+	if private != "" {
+		cd[ConnectionSecretPrivateIPv4Key] = []byte(private)
+	}
+	switch {
+	case public != "":
+		cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(public)
+		cd[xpv1.ResourceCredentialsSecretUserKey] = []byte(RootUser)
+	case private != "":
+		cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(private)
+		cd[xpv1.ResourceCredentialsSecretUserKey] = []byte(RootUser)
+	}
+	return cd
+}
+
+// Features that can be enabled on a Droplet.
+const (
+	FeatureBackups    = "backups"
+	FeatureMonitoring = "monitoring"
+)
+
+// HasFeature returns true if the supplied feature is enabled on the supplied
+// Droplet.
+func HasFeature(observed godo.Droplet, feature string) bool {
+	for _, f := range observed.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsBackups returns true if backups are desired for the supplied active
+// Droplet but aren't enabled yet. Backups are never disabled, as they are
+// only enabled if asked for.
+func NeedsBackups(p v1alpha1.DropletParameters, o v1alpha1.DropletObservation) bool {
+	return do.BoolValue(p.Backups) && !o.Backups && o.Status == v1alpha1.StatusActive
+}
+
 // LateInitializeSpec updates any unset (i.e. nil) optional fields of the
 // supplied DropletParameters that are set (i.e. non-zero) on the supplied
-// Droplet.
+// Droplet. Tags are not late-initialized, as that would adopt every tag added
+// outside of the provider.
 func LateInitializeSpec(p *v1alpha1.DropletParameters, observed godo.Droplet) {
 	p.Volumes = do.LateInitializeStringSlice(p.Volumes, observed.VolumeIDs)
-	p.Tags = do.LateInitializeStringSlice(p.Tags, observed.Tags)
 	p.VPCUUID = do.LateInitializeString(p.VPCUUID, observed.VPCUUID)
 }
@@ -0,0 +1,296 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+)
+
+func TestGenerateConnectionDetails(t *testing.T) {
+	cases := map[string]struct {
+		observed godo.Droplet
+		want     managed.ConnectionDetails
+	}{
+		"NoNetworks": {
+			want: managed.ConnectionDetails{},
+		},
+		"PublicAndPrivate": {
+			observed: godo.Droplet{Networks: &godo.Networks{V4: []godo.NetworkV4{
+				{IPAddress: "10.110.0.2", Type: "private"},
+				{IPAddress: "203.0.113.10", Type: "public"},
+			}}},
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("203.0.113.10"),
+				xpv1.ResourceCredentialsSecretUserKey:     []byte(RootUser),
+				ConnectionSecretPublicIPv4Key:             []byte("203.0.113.10"),
+				ConnectionSecretPrivateIPv4Key:            []byte("10.110.0.2"),
+			},
+		},
+		"NoPublicIP": {
+			observed: godo.Droplet{Networks: &godo.Networks{V4: []godo.NetworkV4{
+				{IPAddress: "10.110.0.2", Type: "private"},
+			}}},
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("10.110.0.2"),
+				xpv1.ResourceCredentialsSecretUserKey:     []byte(RootUser),
+				ConnectionSecretPrivateIPv4Key:            []byte("10.110.0.2"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, GenerateConnectionDetails(tc.observed)); diff != "" {
+				t.Errorf("GenerateConnectionDetails(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type oneClickLister struct {
+	apps []*godo.OneClick
+	err  error
+}
+
+func (l *oneClickLister) List(_ context.Context, t string) ([]*godo.OneClick, *godo.Response, error) {
+	if t != oneClickTypeDroplet {
+		return nil, nil, errors.Errorf("unexpected 1-Click type %q", t)
+	}
+	return l.apps, &godo.Response{}, l.err
+}
+
+func TestValidateOneClickApp(t *testing.T) {
+	errBoom := errors.New("boom")
+	apps := []*godo.OneClick{
+		{Slug: "docker-20-04", Type: oneClickTypeDroplet},
+		{Slug: "wordpress-20-04", Type: oneClickTypeDroplet},
+	}
+
+	cases := map[string]struct {
+		lister *oneClickLister
+		slug   string
+		want   error
+	}{
+		"KnownApp": {
+			lister: &oneClickLister{apps: apps},
+			slug:   "wordpress-20-04",
+		},
+		"UnknownApp": {
+			lister: &oneClickLister{apps: apps},
+			slug:   "ubuntu-20-04-x64",
+			want:   errors.Errorf(errUnknownOneClickApp, "ubuntu-20-04-x64"),
+		},
+		"ListFailed": {
+			lister: &oneClickLister{err: errBoom},
+			slug:   "wordpress-20-04",
+			want:   errBoom,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateOneClickApp(context.Background(), tc.lister, tc.slug)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateOneClickApp(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type vpcGetter struct {
+	vpc *godo.VPC
+	err error
+}
+
+func (g *vpcGetter) Get(_ context.Context, id string) (*godo.VPC, *godo.Response, error) {
+	if g.err != nil {
+		return nil, nil, g.err
+	}
+	if id != g.vpc.ID {
+		return nil, nil, errors.Errorf("unexpected VPC %q", id)
+	}
+	return g.vpc, &godo.Response{}, nil
+}
+
+func TestValidateVPCRegion(t *testing.T) {
+	errBoom := errors.New("boom")
+	vpcID := "5a4981aa-9653-4bd1-bef5-d6bff52042e4"
+	vpc := &godo.VPC{ID: vpcID, RegionSlug: "nyc1"}
+
+	cases := map[string]struct {
+		getter *vpcGetter
+		p      v1alpha1.DropletParameters
+		want   error
+	}{
+		"NoVPC": {
+			getter: &vpcGetter{err: errBoom},
+			p:      v1alpha1.DropletParameters{Region: "nyc1"},
+		},
+		"SameRegion": {
+			getter: &vpcGetter{vpc: vpc},
+			p:      v1alpha1.DropletParameters{Region: "nyc1", VPCUUID: &vpcID},
+		},
+		"RegionMismatch": {
+			getter: &vpcGetter{vpc: vpc},
+			p:      v1alpha1.DropletParameters{Region: "sfo3", VPCUUID: &vpcID},
+			want:   errors.Errorf(errVPCRegionMismatch, "sfo3", "nyc1", vpcID),
+		},
+		"GetFailed": {
+			getter: &vpcGetter{err: errBoom},
+			p:      v1alpha1.DropletParameters{Region: "nyc1", VPCUUID: &vpcID},
+			want:   errors.Wrap(errBoom, errGetVPC),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateVPCRegion(context.Background(), tc.getter, tc.p)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateVPCRegion(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type sizeLister struct {
+	sizes []godo.Size
+	err   error
+}
+
+func (l *sizeLister) List(context.Context, *godo.ListOptions) ([]godo.Size, *godo.Response, error) {
+	return l.sizes, &godo.Response{}, l.err
+}
+
+func TestValidateSizeRegion(t *testing.T) {
+	errBoom := errors.New("boom")
+	sizes := []godo.Size{
+		{Slug: "s-1vcpu-1gb", Available: true, Regions: []string{"nyc1", "sfo3"}},
+		{Slug: "s-8vcpu-16gb", Available: false, Regions: []string{"nyc1"}},
+	}
+
+	cases := map[string]struct {
+		lister *sizeLister
+		p      v1alpha1.DropletParameters
+		want   error
+	}{
+		"Available": {
+			lister: &sizeLister{sizes: sizes},
+			p:      v1alpha1.DropletParameters{Region: "sfo3", Size: "s-1vcpu-1gb"},
+		},
+		"NotInRegion": {
+			lister: &sizeLister{sizes: sizes},
+			p:      v1alpha1.DropletParameters{Region: "ams3", Size: "s-1vcpu-1gb"},
+			want:   errors.Errorf(errSizeUnavailable, "s-1vcpu-1gb", "ams3"),
+		},
+		"SoldOut": {
+			lister: &sizeLister{sizes: sizes},
+			p:      v1alpha1.DropletParameters{Region: "nyc1", Size: "s-8vcpu-16gb"},
+			want:   errors.Errorf(errSizeUnavailable, "s-8vcpu-16gb", "nyc1"),
+		},
+		"UnknownSize": {
+			lister: &sizeLister{sizes: sizes},
+			p:      v1alpha1.DropletParameters{Region: "nyc1", Size: "s-1vcpu-512mb"},
+			want:   errors.Errorf(errSizeUnavailable, "s-1vcpu-512mb", "nyc1"),
+		},
+		"ListFailed": {
+			lister: &sizeLister{err: errBoom},
+			p:      v1alpha1.DropletParameters{Region: "nyc1", Size: "s-1vcpu-1gb"},
+			want:   errors.Wrap(errBoom, errListSizes),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateSizeRegion(context.Background(), tc.lister, tc.p)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateSizeRegion(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNeedsBackups(t *testing.T) {
+	cases := map[string]struct {
+		p    v1alpha1.DropletParameters
+		o    v1alpha1.DropletObservation
+		want bool
+	}{
+		"NotWanted": {
+			o: v1alpha1.DropletObservation{Status: v1alpha1.StatusActive},
+		},
+		"Disabled": {
+			p:    v1alpha1.DropletParameters{Backups: godo.PtrTo(true)},
+			o:    v1alpha1.DropletObservation{Status: v1alpha1.StatusActive},
+			want: true,
+		},
+		"Enabled": {
+			p: v1alpha1.DropletParameters{Backups: godo.PtrTo(true)},
+			o: v1alpha1.DropletObservation{Status: v1alpha1.StatusActive, Backups: true},
+		},
+		"NeverDisabled": {
+			p: v1alpha1.DropletParameters{Backups: godo.PtrTo(false)},
+			o: v1alpha1.DropletObservation{Status: v1alpha1.StatusActive, Backups: true},
+		},
+		"NotActive": {
+			p: v1alpha1.DropletParameters{Backups: godo.PtrTo(true)},
+			o: v1alpha1.DropletObservation{Status: v1alpha1.StatusNew},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := NeedsBackups(tc.p, tc.o); got != tc.want {
+				t.Errorf("NeedsBackups(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	observed := godo.Droplet{
+		VolumeIDs: []string{"volume"},
+		Tags:      []string{"added-outside", "billing:team"},
+		VPCUUID:   "vpc",
+	}
+	cases := map[string]struct {
+		p    v1alpha1.DropletParameters
+		want v1alpha1.DropletParameters
+	}{
+		"FillsUnsetFields": {
+			want: v1alpha1.DropletParameters{Volumes: []string{"volume"}, VPCUUID: godo.PtrTo("vpc")},
+		},
+		"KeepsSetFields": {
+			p:    v1alpha1.DropletParameters{Volumes: []string{"other"}, Tags: []string{"mine"}, VPCUUID: godo.PtrTo("other")},
+			want: v1alpha1.DropletParameters{Volumes: []string{"other"}, Tags: []string{"mine"}, VPCUUID: godo.PtrTo("other")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(&tc.p, observed)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/compute"
+)
+
+// this ensures that the mock implements the client interface
+var _ compute.AssociatedResourcesClient = (*MockAssociatedResourcesClient)(nil)
+
+// MockAssociatedResourcesClient is a type that implements all the methods for AssociatedResourcesClient interface
+type MockAssociatedResourcesClient struct {
+	MockDestroyWithAssociatedResources func(context.Context, int) (*godo.Response, error)
+	MockGetDestroyStatus               func(context.Context, int) (*compute.DestroyStatus, *godo.Response, error)
+}
+
+// DestroyWithAssociatedResources mocks DestroyWithAssociatedResources method
+func (c *MockAssociatedResourcesClient) DestroyWithAssociatedResources(ctx context.Context, id int) (*godo.Response, error) {
+	return c.MockDestroyWithAssociatedResources(ctx, id)
+}
+
+// GetDestroyStatus mocks GetDestroyStatus method
+func (c *MockAssociatedResourcesClient) GetDestroyStatus(ctx context.Context, id int) (*compute.DestroyStatus, *godo.Response, error) {
+	return c.MockGetDestroyStatus(ctx, id)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	errUpdateSecretNamespace = "cannot default the namespace of the connection secret"
+	errGetConnectionSecret   = "cannot get the connection secret"
+)
+
+// ConnectionChecksum returns the hex encoded SHA-256 checksum of the supplied
+// connection details. Keys are hashed in sorted order, so the checksum only
+// changes when the details do.
+func ConnectionChecksum(c managed.ConnectionDetails) string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		// Separate keys and values so that e.g. {"ab": "c"} and {"a": "bc"}
+		// don't hash alike.
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(c[k])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// A ConnectionChecksummer is a managed resource that records the checksum of
+// its connection secret in its status.
+type ConnectionChecksummer interface {
+	resource.Managed
+	GetConnectionChecksum() string
+	SetConnectionChecksum(sum string)
+}
+
+// getConnectionSecretData returns the data of the connection secret the
+// supplied reference points to, or nil if it doesn't exist.
+func getConnectionSecretData(ctx context.Context, kube client.Client, ref *xpv1.SecretReference) (managed.ConnectionDetails, error) {
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errGetConnectionSecret)
+	}
+	return s.Data, nil
+}
+
+// ConnectionDetailsChanged returns true if the connection secret of the
+// supplied managed resource doesn't hold the supplied connection details,
+// e.g. because DigitalOcean moved the external resource to a new host or the
+// secret was deleted. Keys of the secret that aren't part of the details are
+// ignored. It returns false if the managed resource has no connection secret.
+func ConnectionDetailsChanged(ctx context.Context, kube client.Client, mg resource.Managed, c managed.ConnectionDetails) (bool, error) {
+	ref := mg.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return false, nil
+	}
+	data, err := getConnectionSecretData(ctx, kube, ref)
+	if err != nil {
+		return false, err
+	}
+	for k, v := range c {
+		if got, ok := data[k]; !ok || !bytes.Equal(got, v) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// A ChecksumPublisher publishes connection details with the publisher it
+// wraps, then records the checksum of the connection secret in the status of
+// managed resources that are ConnectionChecksummers. The status is written by
+// the managed reconciler along with the rest of it.
+type ChecksumPublisher struct {
+	managed.ConnectionPublisher
+	kube client.Client
+}
+
+// NewChecksumPublisher returns a ChecksumPublisher that publishes connection
+// details with the supplied publisher.
+func NewChecksumPublisher(p managed.ConnectionPublisher, kube client.Client) *ChecksumPublisher {
+	return &ChecksumPublisher{ConnectionPublisher: p, kube: kube}
+}
+
+// PublishConnection details for the supplied managed resource. The wrapped
+// publisher merges the details into the data the secret already holds, so the
+// checksum covers the merged data. Publishing only some of the details thus
+// doesn't change the checksum unless they changed.
+func (p *ChecksumPublisher) PublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	ref := mg.GetWriteConnectionSecretToReference()
+	cm, ok := mg.(ConnectionChecksummer)
+	if !ok || ref == nil || len(c) == 0 {
+		return p.ConnectionPublisher.PublishConnection(ctx, mg, c)
+	}
+	existing, err := getConnectionSecretData(ctx, p.kube, ref)
+	if err != nil {
+		return err
+	}
+	if err := p.ConnectionPublisher.PublishConnection(ctx, mg, c); err != nil {
+		return err
+	}
+	data := make(managed.ConnectionDetails, len(existing)+len(c))
+	for k, v := range existing {
+		data[k] = v
+	}
+	for k, v := range c {
+		data[k] = v
+	}
+	cm.SetConnectionChecksum(ConnectionChecksum(data))
+	return nil
+}
+
+// A DefaultConnectionSecretNamespace initializes the namespace of the
+// connection secret of managed resources that don't specify one, so that
+// their connection secrets are written to a fixed namespace.
+type DefaultConnectionSecretNamespace struct {
+	kube      client.Client
+	namespace string
+}
+
+// NewDefaultConnectionSecretNamespace returns an initializer that defaults
+// the namespace of connection secrets to the supplied namespace. Nothing is
+// defaulted if the namespace is empty.
+func NewDefaultConnectionSecretNamespace(kube client.Client, namespace string) *DefaultConnectionSecretNamespace {
+	return &DefaultConnectionSecretNamespace{kube: kube, namespace: namespace}
+}
+
+// Initialize the namespace of the connection secret of the supplied managed
+// resource. A namespace the resource specifies is left as is.
+func (a *DefaultConnectionSecretNamespace) Initialize(ctx context.Context, mg resource.Managed) error {
+	ref := mg.GetWriteConnectionSecretToReference()
+	if a.namespace == "" || ref == nil || ref.Namespace != "" {
+		return nil
+	}
+	ref.Namespace = a.namespace
+	mg.SetWriteConnectionSecretToReference(ref)
+	return errors.Wrap(a.kube.Update(ctx, mg), errUpdateSecretNamespace)
+}
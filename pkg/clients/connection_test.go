@@ -0,0 +1,315 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestConnectionChecksum(t *testing.T) {
+	a := managed.ConnectionDetails{"username": []byte("doadmin"), "password": []byte("secret")}
+	b := managed.ConnectionDetails{"password": []byte("secret"), "username": []byte("doadmin")}
+	if ConnectionChecksum(a) != ConnectionChecksum(b) {
+		t.Errorf("ConnectionChecksum(...): checksum depends on key order")
+	}
+
+	rotated := managed.ConnectionDetails{"username": []byte("doadmin"), "password": []byte("rotated")}
+	if ConnectionChecksum(a) == ConnectionChecksum(rotated) {
+		t.Errorf("ConnectionChecksum(...): checksum didn't change with the details")
+	}
+
+	shifted := managed.ConnectionDetails{"usernam": []byte("edoadmin"), "password": []byte("secret")}
+	if ConnectionChecksum(a) == ConnectionChecksum(shifted) {
+		t.Errorf("ConnectionChecksum(...): keys and values aren't separated")
+	}
+}
+
+type checksummed struct {
+	fake.Managed
+	checksum string
+}
+
+func (m *checksummed) GetConnectionChecksum() string    { return m.checksum }
+func (m *checksummed) SetConnectionChecksum(sum string) { m.checksum = sum }
+
+func TestChecksumPublisher(t *testing.T) {
+	host := managed.ConnectionDetails{"host": []byte("db.example.org")}
+	all := managed.ConnectionDetails{"host": []byte("db.example.org"), "app.password": []byte("secret")}
+	errBoom := errors.New("boom")
+
+	withSecret := func() *checksummed {
+		return &checksummed{Managed: fake.Managed{ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{Name: "conn"}}}}
+	}
+	secret := func(data map[string][]byte) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}
+	}
+	notFound := func(_ context.Context, key client.ObjectKey, _ client.Object) error {
+		return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+	}
+
+	type want struct {
+		err      error
+		checksum string
+	}
+	cases := map[string]struct {
+		mg      resource.Managed
+		get     test.MockGetFn
+		details managed.ConnectionDetails
+		publish error
+		want    want
+	}{
+		"NewSecret": {
+			mg:      withSecret(),
+			get:     notFound,
+			details: all,
+			want:    want{checksum: ConnectionChecksum(all)},
+		},
+		"ChecksumCoversMergedData": {
+			// Publishing only some of the details yields the same checksum as
+			// publishing all of them if the others are unchanged.
+			mg:      withSecret(),
+			get:     secret(all),
+			details: host,
+			want:    want{checksum: ConnectionChecksum(all)},
+		},
+		"DetailsChanged": {
+			mg:      withSecret(),
+			get:     secret(all),
+			details: managed.ConnectionDetails{"host": []byte("moved.example.org")},
+			want:    want{checksum: ConnectionChecksum(managed.ConnectionDetails{"host": []byte("moved.example.org"), "app.password": []byte("secret")})},
+		},
+		"NoDetails": {
+			mg: withSecret(),
+		},
+		"NoSecret": {
+			mg:      &checksummed{},
+			details: all,
+		},
+		"NotAChecksummer": {
+			mg:      &fake.Managed{ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{Name: "conn"}}},
+			details: all,
+		},
+		"GetFailed": {
+			mg:      withSecret(),
+			get:     test.NewMockGetFn(errBoom),
+			details: all,
+			want:    want{err: errors.Wrap(errBoom, errGetConnectionSecret)},
+		},
+		"PublishFailed": {
+			mg:      withSecret(),
+			get:     notFound,
+			details: all,
+			publish: errBoom,
+			want:    want{err: errBoom},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := NewChecksumPublisher(managed.ConnectionPublisherFns{
+				PublishConnectionFn: func(context.Context, resource.Managed, managed.ConnectionDetails) error { return tc.publish },
+			}, &test.MockClient{MockGet: tc.get})
+			err := p.PublishConnection(context.Background(), tc.mg, tc.details)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("PublishConnection(...): -want error, +got error:\n%s", diff)
+			}
+			got := ""
+			if cm, ok := tc.mg.(ConnectionChecksummer); ok {
+				got = cm.GetConnectionChecksum()
+			}
+			if diff := cmp.Diff(tc.want.checksum, got); diff != "" {
+				t.Errorf("PublishConnection(...): -want checksum, +got checksum:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConnectionDetailsChanged(t *testing.T) {
+	errBoom := errors.New("boom")
+	details := managed.ConnectionDetails{"host": []byte("db.example.org"), "port": []byte("25060")}
+	withSecret := &fake.Managed{ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{Name: "conn", Namespace: "default"}}}
+	secret := func(data map[string][]byte) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Name != "conn" || key.Namespace != "default" {
+				return errors.Errorf("unexpected key %s", key)
+			}
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}
+	}
+
+	type want struct {
+		changed bool
+		err     error
+	}
+	cases := map[string]struct {
+		mg   *fake.Managed
+		get  test.MockGetFn
+		want want
+	}{
+		"Unchanged": {
+			mg:  withSecret,
+			get: secret(map[string][]byte{"host": []byte("db.example.org"), "port": []byte("25060"), "extra": []byte("kept")}),
+		},
+		"HostChanged": {
+			mg:   withSecret,
+			get:  secret(map[string][]byte{"host": []byte("old.example.org"), "port": []byte("25060")}),
+			want: want{changed: true},
+		},
+		"KeyMissing": {
+			mg:   withSecret,
+			get:  secret(map[string][]byte{"host": []byte("db.example.org")}),
+			want: want{changed: true},
+		},
+		"SecretNotFound": {
+			mg: withSecret,
+			get: func(_ context.Context, key client.ObjectKey, _ client.Object) error {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+			},
+			want: want{changed: true},
+		},
+		"GetFailed": {
+			mg:   withSecret,
+			get:  test.NewMockGetFn(errBoom),
+			want: want{err: errors.Wrap(errBoom, errGetConnectionSecret)},
+		},
+		"NoSecret": {
+			mg: &fake.Managed{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed, err := ConnectionDetailsChanged(context.Background(), &test.MockClient{MockGet: tc.get}, tc.mg, details)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ConnectionDetailsChanged(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("ConnectionDetailsChanged(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultConnectionSecretNamespace(t *testing.T) {
+	withSecret := func(namespace string) *fake.Managed {
+		return &fake.Managed{ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{Name: "conn", Namespace: namespace}}}
+	}
+
+	type want struct {
+		updated   bool
+		namespace string
+	}
+
+	cases := map[string]struct {
+		mg        *fake.Managed
+		namespace string
+		want      want
+	}{
+		"DefaultsNamespace": {
+			mg:        withSecret(""),
+			namespace: "do-secrets",
+			want:      want{updated: true, namespace: "do-secrets"},
+		},
+		"ResourceNamespaceWins": {
+			mg:        withSecret("team-a"),
+			namespace: "do-secrets",
+			want:      want{namespace: "team-a"},
+		},
+		"NoDefault": {
+			mg:   withSecret(""),
+			want: want{namespace: ""},
+		},
+		"NoSecret": {
+			mg:        &fake.Managed{},
+			namespace: "do-secrets",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			kube := &test.MockClient{MockUpdate: func(context.Context, client.Object, ...client.UpdateOption) error {
+				updated = true
+				return nil
+			}}
+			err := NewDefaultConnectionSecretNamespace(kube, tc.namespace).Initialize(context.Background(), tc.mg)
+			if err != nil {
+				t.Errorf("Initialize(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("Initialize(...): -want updated, +got updated:\n%s", diff)
+			}
+			if ref := tc.mg.GetWriteConnectionSecretToReference(); ref != nil {
+				if diff := cmp.Diff(tc.want.namespace, ref.Namespace); diff != "" {
+					t.Errorf("Initialize(...): -want namespace, +got namespace:\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestConnectionSecretNamespaceOptional(t *testing.T) {
+	// A reference without a namespace must be accepted by every CRD, so that
+	// DefaultConnectionSecretNamespace can default it.
+	ref := xpv1.SecretReference{Name: "conn"}
+	set := map[string]bool{"name": ref.Name != "", "namespace": ref.Namespace != ""}
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "package", "crds", "*.yaml"))
+	if err != nil {
+		t.Fatalf("filepath.Glob(...): %v", err)
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("os.ReadFile(%q): %v", f, err)
+		}
+		crd := &extv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(b, crd); err != nil {
+			t.Fatalf("yaml.Unmarshal(%q): %v", f, err)
+		}
+		for _, v := range crd.Spec.Versions {
+			s, ok := v.Schema.OpenAPIV3Schema.Properties["spec"].Properties["writeConnectionSecretToRef"]
+			if !ok {
+				continue
+			}
+			for _, r := range s.Required {
+				if !set[r] {
+					t.Errorf("%s %s: writeConnectionSecretToRef requires %q", crd.GetName(), v.Name, r)
+				}
+			}
+		}
+	}
+}
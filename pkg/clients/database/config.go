@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"strings"
+	"time"
+
+	// The time zone database is embedded so that time zones can be
+	// validated in images without one.
+	_ "time/tzdata"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+)
+
+const (
+	errUnsupportedRedisValue = "unsupported Redis %s %q, must be one of: %s"
+	errConfigEngineMismatch  = "%s configuration can't be set for a %q cluster"
+	errConfigBothEngines     = "postgresql and redis configuration can't both be set"
+	errUnknownTimezone       = "unknown PostgreSQL timezone %q, must be the name of a zone in the IANA time zone database"
+)
+
+// RedisPersistenceValues returns the Redis persistence modes DigitalOcean
+// accepts.
+func RedisPersistenceValues() []string {
+	return []string{"off", "rdb"}
+}
+
+// RedisMaxmemoryPolicyValues returns the Redis eviction policies DigitalOcean
+// accepts.
+func RedisMaxmemoryPolicyValues() []string {
+	return []string{"noeviction", "allkeys-lru", "allkeys-random", "volatile-lru", "volatile-random", "volatile-ttl"}
+}
+
+// GeneratePostgreSQLConfig generates *godo.PostgreSQLConfig instance from
+// DODatabaseClusterPostgreSQLConfig. Unset fields are left nil so that they
+// are omitted from the update and keep their current value.
+func GeneratePostgreSQLConfig(in v1alpha1.DODatabaseClusterPostgreSQLConfig) *godo.PostgreSQLConfig {
+	return &godo.PostgreSQLConfig{
+		WorkMem:                         in.WorkMem,
+		SharedBuffersPercentage:         in.SharedBuffersPercentage,
+		Timezone:                        in.Timezone,
+		IdleInTransactionSessionTimeout: in.IdleInTransactionSessionTimeout,
+		LogMinDurationStatement:         in.LogMinDurationStatement,
+		TempFileLimit:                   in.TempFileLimit,
+	}
+}
+
+// IsPostgreSQLConfigUpToDate returns true if every field set on the supplied
+// DODatabaseClusterPostgreSQLConfig matches the observed config.
+func IsPostgreSQLConfigUpToDate(in v1alpha1.DODatabaseClusterPostgreSQLConfig, observed godo.PostgreSQLConfig) bool {
+	return intUpToDate(in.WorkMem, observed.WorkMem) &&
+		float32UpToDate(in.SharedBuffersPercentage, observed.SharedBuffersPercentage) &&
+		stringUpToDate(in.Timezone, observed.Timezone) &&
+		intUpToDate(in.IdleInTransactionSessionTimeout, observed.IdleInTransactionSessionTimeout) &&
+		intUpToDate(in.LogMinDurationStatement, observed.LogMinDurationStatement) &&
+		intUpToDate(in.TempFileLimit, observed.TempFileLimit)
+}
+
+// HasPostgreSQLConfig returns true if PostgreSQL config is specified for the
+// supplied DODatabaseClusterParameters.
+func HasPostgreSQLConfig(p v1alpha1.DODatabaseClusterParameters) bool {
+	return p.Config != nil && p.Config.PostgreSQL != nil
+}
+
+// GenerateRedisConfig generates *godo.RedisConfig instance from
+// DODatabaseClusterRedisConfig. Unset fields are left nil so that they are
+// omitted from the update and keep their current value.
+func GenerateRedisConfig(in v1alpha1.DODatabaseClusterRedisConfig) *godo.RedisConfig {
+	return &godo.RedisConfig{
+		RedisPersistence:          in.Persistence,
+		RedisTimeout:              in.Timeout,
+		RedisMaxmemoryPolicy:      in.MaxmemoryPolicy,
+		RedisNotifyKeyspaceEvents: in.NotifyKeyspaceEvents,
+	}
+}
+
+// IsRedisConfigUpToDate returns true if every field set on the supplied
+// DODatabaseClusterRedisConfig matches the observed config.
+func IsRedisConfigUpToDate(in v1alpha1.DODatabaseClusterRedisConfig, observed godo.RedisConfig) bool {
+	return stringUpToDate(in.Persistence, observed.RedisPersistence) &&
+		intUpToDate(in.Timeout, observed.RedisTimeout) &&
+		stringUpToDate(in.MaxmemoryPolicy, observed.RedisMaxmemoryPolicy) &&
+		stringUpToDate(in.NotifyKeyspaceEvents, observed.RedisNotifyKeyspaceEvents)
+}
+
+// HasRedisConfig returns true if Redis config is specified for the supplied
+// DODatabaseClusterParameters.
+func HasRedisConfig(p v1alpha1.DODatabaseClusterParameters) bool {
+	return p.Config != nil && p.Config.Redis != nil
+}
+
+// ValidateConfig returns an error if the configuration of the supplied
+// DODatabaseClusterParameters isn't valid for a cluster of the supplied
+// engine, either because it configures both engines, because it configures
+// another engine or because it sets a value DigitalOcean doesn't accept.
+func ValidateConfig(p v1alpha1.DODatabaseClusterParameters, engine string) error {
+	if HasPostgreSQLConfig(p) && HasRedisConfig(p) {
+		return errors.New(errConfigBothEngines)
+	}
+	if HasPostgreSQLConfig(p) {
+		if engine != v1alpha1.EnginePostgreSQL {
+			return errors.Errorf(errConfigEngineMismatch, "postgresql", engine)
+		}
+		return ValidatePostgreSQLConfig(*p.Config.PostgreSQL)
+	}
+	if HasRedisConfig(p) {
+		if engine != v1alpha1.EngineRedis {
+			return errors.Errorf(errConfigEngineMismatch, "redis", engine)
+		}
+		return ValidateRedisConfig(*p.Config.Redis)
+	}
+	return nil
+}
+
+// ValidatePostgreSQLConfig returns an error if the timezone of the supplied
+// DODatabaseClusterPostgreSQLConfig isn't a zone of the IANA time zone
+// database.
+func ValidatePostgreSQLConfig(in v1alpha1.DODatabaseClusterPostgreSQLConfig) error {
+	if in.Timezone == nil {
+		return nil
+	}
+	// LoadLocation also accepts "" and "Local", which aren't zones.
+	tz := *in.Timezone
+	if _, err := time.LoadLocation(tz); err != nil || tz == "" || tz == "Local" {
+		return errors.Errorf(errUnknownTimezone, tz)
+	}
+	return nil
+}
+
+// ValidateRedisConfig returns an error if any enum-style field of the supplied
+// DODatabaseClusterRedisConfig is set to a value DigitalOcean doesn't accept.
+func ValidateRedisConfig(in v1alpha1.DODatabaseClusterRedisConfig) error {
+	if err := validateOneOf("persistence", in.Persistence, RedisPersistenceValues()); err != nil {
+		return err
+	}
+	return validateOneOf("maxmemoryPolicy", in.MaxmemoryPolicy, RedisMaxmemoryPolicyValues())
+}
+
+func validateOneOf(field string, v *string, accepted []string) error {
+	if v == nil {
+		return nil
+	}
+	for _, a := range accepted {
+		if *v == a {
+			return nil
+		}
+	}
+	return errors.Errorf(errUnsupportedRedisValue, field, *v, strings.Join(accepted, ", "))
+}
+
+func intUpToDate(want, got *int) bool {
+	return want == nil || (got != nil && *want == *got)
+}
+
+func float32UpToDate(want, got *float32) bool {
+	return want == nil || (got != nil && *want == *got)
+}
+
+func stringUpToDate(want, got *string) bool {
+	return want == nil || (got != nil && *want == *got)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+)
+
+func TestIsPostgreSQLConfigUpToDate(t *testing.T) {
+	observed := godo.PostgreSQLConfig{
+		WorkMem:                 godo.PtrTo(4),
+		SharedBuffersPercentage: godo.PtrTo(float32(25)),
+		Timezone:                godo.PtrTo("UTC"),
+		JIT:                     godo.PtrTo(true),
+	}
+
+	cases := map[string]struct {
+		in   v1alpha1.DODatabaseClusterPostgreSQLConfig
+		want bool
+	}{
+		"NothingSpecified": {
+			want: true,
+		},
+		"Matches": {
+			in:   v1alpha1.DODatabaseClusterPostgreSQLConfig{WorkMem: godo.PtrTo(4), Timezone: godo.PtrTo("UTC")},
+			want: true,
+		},
+		"Drifted": {
+			in:   v1alpha1.DODatabaseClusterPostgreSQLConfig{WorkMem: godo.PtrTo(16)},
+			want: false,
+		},
+		"NotObserved": {
+			in:   v1alpha1.DODatabaseClusterPostgreSQLConfig{TempFileLimit: godo.PtrTo(-1)},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsPostgreSQLConfigUpToDate(tc.in, observed); got != tc.want {
+				t.Errorf("IsPostgreSQLConfigUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGeneratePostgreSQLConfig(t *testing.T) {
+	in := v1alpha1.DODatabaseClusterPostgreSQLConfig{WorkMem: godo.PtrTo(16), Timezone: godo.PtrTo("Europe/Helsinki")}
+	want := &godo.PostgreSQLConfig{WorkMem: godo.PtrTo(16), Timezone: godo.PtrTo("Europe/Helsinki")}
+	if diff := cmp.Diff(want, GeneratePostgreSQLConfig(in)); diff != "" {
+		t.Errorf("GeneratePostgreSQLConfig(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsRedisConfigUpToDate(t *testing.T) {
+	observed := godo.RedisConfig{
+		RedisPersistence:     godo.PtrTo("rdb"),
+		RedisTimeout:         godo.PtrTo(300),
+		RedisMaxmemoryPolicy: godo.PtrTo("allkeys-lru"),
+		RedisIOThreads:       godo.PtrTo(1),
+	}
+
+	cases := map[string]struct {
+		in   v1alpha1.DODatabaseClusterRedisConfig
+		want bool
+	}{
+		"NothingSpecified": {
+			want: true,
+		},
+		"Matches": {
+			in:   v1alpha1.DODatabaseClusterRedisConfig{Persistence: godo.PtrTo("rdb"), Timeout: godo.PtrTo(300)},
+			want: true,
+		},
+		"Drifted": {
+			in:   v1alpha1.DODatabaseClusterRedisConfig{MaxmemoryPolicy: godo.PtrTo("noeviction")},
+			want: false,
+		},
+		"NotObserved": {
+			in:   v1alpha1.DODatabaseClusterRedisConfig{NotifyKeyspaceEvents: godo.PtrTo("Ex")},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsRedisConfigUpToDate(tc.in, observed); got != tc.want {
+				t.Errorf("IsRedisConfigUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateRedisConfig(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.DODatabaseClusterRedisConfig
+		want error
+	}{
+		"NothingSpecified": {},
+		"Valid": {
+			in: v1alpha1.DODatabaseClusterRedisConfig{Persistence: godo.PtrTo("off"), MaxmemoryPolicy: godo.PtrTo("volatile-ttl")},
+		},
+		"InvalidPersistence": {
+			in:   v1alpha1.DODatabaseClusterRedisConfig{Persistence: godo.PtrTo("aof")},
+			want: errors.Errorf(errUnsupportedRedisValue, "persistence", "aof", "off, rdb"),
+		},
+		"InvalidMaxmemoryPolicy": {
+			in:   v1alpha1.DODatabaseClusterRedisConfig{MaxmemoryPolicy: godo.PtrTo("allkeys-lfu")},
+			want: errors.Errorf(errUnsupportedRedisValue, "maxmemoryPolicy", "allkeys-lfu", strings.Join(RedisMaxmemoryPolicyValues(), ", ")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ValidateRedisConfig(tc.in), test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateRedisConfig(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePostgreSQLConfig(t *testing.T) {
+	cases := map[string]struct {
+		timezone *string
+		want     error
+	}{
+		"Unset": {},
+		"Zone": {
+			timezone: godo.PtrTo("Europe/Helsinki"),
+		},
+		"UTC": {
+			timezone: godo.PtrTo("UTC"),
+		},
+		"Unknown": {
+			timezone: godo.PtrTo("Mars/Olympus_Mons"),
+			want:     errors.Errorf(errUnknownTimezone, "Mars/Olympus_Mons"),
+		},
+		"Empty": {
+			timezone: godo.PtrTo(""),
+			want:     errors.Errorf(errUnknownTimezone, ""),
+		},
+		"Local": {
+			timezone: godo.PtrTo("Local"),
+			want:     errors.Errorf(errUnknownTimezone, "Local"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidatePostgreSQLConfig(v1alpha1.DODatabaseClusterPostgreSQLConfig{Timezone: tc.timezone})
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidatePostgreSQLConfig(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	pg := &v1alpha1.DODatabaseClusterPostgreSQLConfig{WorkMem: godo.PtrTo(16)}
+	redis := &v1alpha1.DODatabaseClusterRedisConfig{Persistence: godo.PtrTo("rdb"), MaxmemoryPolicy: godo.PtrTo("allkeys-lru")}
+
+	cases := map[string]struct {
+		config *v1alpha1.DODatabaseClusterConfig
+		engine string
+		want   error
+	}{
+		"NoConfig": {
+			engine: v1alpha1.EngineMySQL,
+		},
+		"PostgreSQL": {
+			config: &v1alpha1.DODatabaseClusterConfig{PostgreSQL: pg},
+			engine: v1alpha1.EnginePostgreSQL,
+		},
+		"Redis": {
+			config: &v1alpha1.DODatabaseClusterConfig{Redis: redis},
+			engine: v1alpha1.EngineRedis,
+		},
+		"PostgreSQLConfigOnRedis": {
+			config: &v1alpha1.DODatabaseClusterConfig{PostgreSQL: pg},
+			engine: v1alpha1.EngineRedis,
+			want:   errors.Errorf(errConfigEngineMismatch, "postgresql", v1alpha1.EngineRedis),
+		},
+		"RedisConfigOnMySQL": {
+			config: &v1alpha1.DODatabaseClusterConfig{Redis: redis},
+			engine: v1alpha1.EngineMySQL,
+			want:   errors.Errorf(errConfigEngineMismatch, "redis", v1alpha1.EngineMySQL),
+		},
+		"BothOnPostgreSQL": {
+			config: &v1alpha1.DODatabaseClusterConfig{PostgreSQL: pg, Redis: redis},
+			engine: v1alpha1.EnginePostgreSQL,
+			want:   errors.New(errConfigBothEngines),
+		},
+		"BothOnRedis": {
+			config: &v1alpha1.DODatabaseClusterConfig{PostgreSQL: pg, Redis: redis},
+			engine: v1alpha1.EngineRedis,
+			want:   errors.New(errConfigBothEngines),
+		},
+		"UnknownTimezone": {
+			config: &v1alpha1.DODatabaseClusterConfig{PostgreSQL: &v1alpha1.DODatabaseClusterPostgreSQLConfig{Timezone: godo.PtrTo("Europe/Atlantis")}},
+			engine: v1alpha1.EnginePostgreSQL,
+			want:   errors.Errorf(errUnknownTimezone, "Europe/Atlantis"),
+		},
+		"InvalidRedisValue": {
+			config: &v1alpha1.DODatabaseClusterConfig{Redis: &v1alpha1.DODatabaseClusterRedisConfig{Persistence: godo.PtrTo("aof")}},
+			engine: v1alpha1.EngineRedis,
+			want:   errors.Errorf(errUnsupportedRedisValue, "persistence", "aof", "off, rdb"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateConfig(v1alpha1.DODatabaseClusterParameters{Config: tc.config}, tc.engine)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateConfig(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -14,12 +14,276 @@ limitations under the License.
 package database
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+// ConnectionSecretCAKey is the connection secret key the cluster's CA
+// certificate is written to when SSL verification is requested.
+const ConnectionSecretCAKey = "ca.crt"
+
+const (
+	// AnnotationPausedConfig records the size and node count a paused
+	// DODatabaseCluster had before it was paused, so it can be restored.
+	AnnotationPausedConfig = "database.do.crossplane.io/paused-config"
+
+	// PausedNumNodes is the node count a DODatabaseCluster is resized to when
+	// paused.
+	PausedNumNodes = 1
+)
+
+// TypePaused DODatabaseClusters are resized to their paused size.
+const TypePaused xpv1.ConditionType = "Paused"
+
+// Reasons a DODatabaseCluster is or is not paused.
+const (
+	ReasonPaused      xpv1.ConditionReason = "Paused"
+	ReasonResumed     xpv1.ConditionReason = "Resumed"
+	ReasonCannotPause xpv1.ConditionReason = "CannotPause"
+)
+
+// Paused returns a condition that indicates the cluster is resized to its
+// paused size.
+func Paused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+	}
+}
+
+// Resumed returns a condition that indicates the cluster has been resized
+// back to the size it had before it was paused.
+func Resumed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
+
+// CannotPause returns a condition that indicates the cluster can't be paused
+// because DigitalOcean offers no size to resize it to.
+func CannotPause(o v1alpha1.DODatabaseClusterObservation) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCannotPause,
+		Message:            fmt.Sprintf("cannot pause: no size of single node %q clusters is at least as large as %q", o.Engine, o.Size),
+	}
+}
+
+// A PausedConfig is the size and node count of a DODatabaseCluster before it
+// was paused.
+type PausedConfig struct {
+	Size     string `json:"size"`
+	NumNodes int    `json:"numNodes"`
+}
+
+// DatabaseClient is the external client used for DODatabaseCluster Custom Resource
+type DatabaseClient interface {
+	Get(context.Context, string) (*godo.Database, *godo.Response, error)
+	List(context.Context, *godo.ListOptions) ([]godo.Database, *godo.Response, error)
+	ListOptions(context.Context) (*godo.DatabaseOptions, *godo.Response, error)
+	GetCA(context.Context, string) (*godo.DatabaseCA, *godo.Response, error)
+	GetFirewallRules(context.Context, string) ([]godo.DatabaseFirewallRule, *godo.Response, error)
+	UpdateFirewallRules(context.Context, string, *godo.DatabaseUpdateFirewallRulesRequest) (*godo.Response, error)
+	Create(context.Context, *godo.DatabaseCreateRequest) (*godo.Database, *godo.Response, error)
+	CreateDB(context.Context, string, *godo.DatabaseCreateDBRequest) (*godo.DatabaseDB, *godo.Response, error)
+	ListReplicas(context.Context, string, *godo.ListOptions) ([]godo.DatabaseReplica, *godo.Response, error)
+	CreateUser(context.Context, string, *godo.DatabaseCreateUserRequest) (*godo.DatabaseUser, *godo.Response, error)
+	DeleteUser(context.Context, string, string) (*godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	Resize(context.Context, string, *godo.DatabaseResizeRequest) (*godo.Response, error)
+	GetPostgreSQLConfig(context.Context, string) (*godo.PostgreSQLConfig, *godo.Response, error)
+	UpdatePostgreSQLConfig(context.Context, string, *godo.PostgreSQLConfig) (*godo.Response, error)
+	GetRedisConfig(context.Context, string) (*godo.RedisConfig, *godo.Response, error)
+	UpdateRedisConfig(context.Context, string, *godo.RedisConfig) (*godo.Response, error)
+}
+
+const (
+	errListOptions         = "cannot list the options of Database Clusters"
+	errEngineUnavailable   = "the database engine %q is not available"
+	errRegionUnavailable   = "the database engine %q is not available in region %q"
+	errVersionUnavailable  = "version %q of the database engine %q is not available"
+	errSizeUnavailable     = "the size %q is not available for %d %s nodes"
+	errNumNodesUnavailable = "%d %s nodes are not supported"
+)
+
+// ValidateAvailability returns an error if the engine, version, region, size
+// or number of nodes of the supplied DODatabaseClusterParameters isn't one
+// DigitalOcean offers. DigitalOcean would reject such a cluster, but with a
+// less helpful error.
+func ValidateAvailability(ctx context.Context, c DatabaseClient, p v1alpha1.DODatabaseClusterParameters) error {
+	options, _, err := c.ListOptions(ctx)
+	if err != nil {
+		return errors.Wrap(err, errListOptions)
+	}
+	engine := do.StringValue(p.Engine)
+	o, ok := engineOptions(options, engine)
+	if !ok {
+		return errors.Errorf(errEngineUnavailable, engine)
+	}
+	if !contains(o.Regions, p.Region) {
+		return errors.Errorf(errRegionUnavailable, engine, p.Region)
+	}
+	if v := do.StringValue(p.Version); v != "" && !contains(o.Versions, v) {
+		return errors.Errorf(errVersionUnavailable, v, engine)
+	}
+	for _, l := range o.Layouts {
+		if l.NodeNum != p.NumNodes {
+			continue
+		}
+		if !contains(l.Sizes, p.Size) {
+			return errors.Errorf(errSizeUnavailable, p.Size, p.NumNodes, engine)
+		}
+		return nil
+	}
+	return errors.Errorf(errNumNodesUnavailable, p.NumNodes, engine)
+}
+
+// PausedSize returns the size the supplied cluster is resized to when paused:
+// the smallest size DigitalOcean offers single node clusters of its engine
+// that isn't smaller than its current size, as the disk of a cluster can't
+// shrink. DigitalOcean lists sizes from smallest to largest. It returns false
+// if there is no such size.
+func PausedSize(options *godo.DatabaseOptions, o v1alpha1.DODatabaseClusterObservation) (string, bool) {
+	eo, ok := engineOptions(options, o.Engine)
+	if !ok {
+		return "", false
+	}
+	single, current := layoutSizes(eo, PausedNumNodes), layoutSizes(eo, o.NumNodes)
+	for i, s := range current {
+		if s != o.Size {
+			continue
+		}
+		for _, size := range current[i:] {
+			if contains(single, size) {
+				return size, true
+			}
+		}
+	}
+	return "", false
+}
+
+func engineOptions(options *godo.DatabaseOptions, engine string) (godo.DatabaseEngineOptions, bool) {
+	switch engine {
+	case v1alpha1.EnginePostgreSQL:
+		return options.PostgresSQLOptions, true
+	case v1alpha1.EngineMySQL:
+		return options.MySQLOptions, true
+	case v1alpha1.EngineRedis:
+		return options.RedisOptions, true
+	case v1alpha1.EngineMongoDB:
+		return options.MongoDBOptions, true
+	}
+	return godo.DatabaseEngineOptions{}, false
+}
+
+func layoutSizes(o godo.DatabaseEngineOptions, numNodes int) []string {
+	for _, l := range o.Layouts {
+		if l.NodeNum == numNodes {
+			return l.Sizes
+		}
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ListByTag returns every Database Cluster that has the supplied tag. The
+// DigitalOcean API can't filter clusters by tag, so all clusters are listed
+// and filtered client side.
+func ListByTag(ctx context.Context, c DatabaseClient, tag string) ([]godo.Database, error) {
+	var dbs []godo.Database
+	opt := &godo.ListOptions{PerPage: do.MaxListPerPage}
+	for {
+		page, resp, err := c.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		dbs = append(dbs, FilterByTag(page, tag)...)
+		more, err := do.NextPage(resp, opt)
+		if err != nil || !more {
+			return dbs, err
+		}
+	}
+}
+
+// FindByName returns the Database Cluster with the supplied name, or nil if
+// there is none. Cluster names are unique within a DigitalOcean account.
+func FindByName(ctx context.Context, c DatabaseClient, name string) (*godo.Database, error) {
+	opt := &godo.ListOptions{PerPage: do.MaxListPerPage}
+	for {
+		page, resp, err := c.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page {
+			if page[i].Name == name {
+				return &page[i], nil
+			}
+		}
+		more, err := do.NextPage(resp, opt)
+		if err != nil || !more {
+			return nil, err
+		}
+	}
+}
+
+// WasCreatedFor returns true if the supplied observed cluster looks like the
+// one that would be created for the supplied parameters by a managed resource
+// that was created at the supplied time. A cluster that existed before the
+// managed resource can't have been created by it.
+func WasCreatedFor(observed godo.Database, p v1alpha1.DODatabaseClusterParameters, created time.Time) bool {
+	return observed.EngineSlug == do.StringValue(p.Engine) &&
+		observed.RegionSlug == p.Region &&
+		!observed.CreatedAt.Before(created)
+}
+
+// FilterByTag returns the supplied Database Clusters that have the supplied
+// tag.
+func FilterByTag(dbs []godo.Database, tag string) []godo.Database {
+	var out []godo.Database
+	for _, db := range dbs {
+		for _, t := range db.Tags {
+			if t == tag {
+				out = append(out, db)
+				break
+			}
+		}
+	}
+	return out
+}
+
 // GenerateDatabase generates *godo.DatabaseRequest instance from LBParameters.
 func GenerateDatabase(name string, in v1alpha1.DODatabaseClusterParameters, create *godo.DatabaseCreateRequest) {
 	create.Name = name
@@ -30,6 +294,258 @@ func GenerateDatabase(name string, in v1alpha1.DODatabaseClusterParameters, crea
 	create.Region = in.Region
 	create.PrivateNetworkUUID = do.StringValue(in.PrivateNetworkUUID)
 	create.Tags = in.Tags
+	if in.Fork != nil {
+		create.BackupRestore = &godo.DatabaseBackupRestore{
+			DatabaseName:    in.Fork.SourceClusterName,
+			BackupCreatedAt: do.StringValue(in.Fork.BackupCreatedAt),
+		}
+	}
+}
+
+// GenerateObservedParameters generates the DODatabaseClusterParameters of the
+// supplied observed godo.Database. Only the parameters DigitalOcean reports
+// are set, which are all those a cluster is created with apart from the
+// backup it may have been forked from.
+func GenerateObservedParameters(observed godo.Database) *v1alpha1.DODatabaseClusterParameters {
+	p := &v1alpha1.DODatabaseClusterParameters{
+		Engine:   do.LateInitializeString(nil, observed.EngineSlug),
+		Version:  do.LateInitializeString(nil, observed.VersionSlug),
+		NumNodes: observed.NumNodes,
+		Size:     observed.SizeSlug,
+		Region:   observed.RegionSlug,
+
+		PrivateNetworkUUID: do.LateInitializeString(nil, observed.PrivateNetworkUUID),
+	}
+	if len(observed.Tags) > 0 {
+		p.Tags = append([]string(nil), observed.Tags...)
+	}
+	return p
+}
+
+// GenerateConnectionDetails generates the managed.ConnectionDetails that will
+// be published to the connection secret of a DODatabaseCluster from the
+// supplied godo.Database, honouring the supplied connection parameters. The
+// CA certificate is only used when verify-full SSL mode is requested.
+func GenerateConnectionDetails(db *godo.Database, p *v1alpha1.DODatabaseClusterConnectionParameters, ca []byte) managed.ConnectionDetails {
+	if db == nil {
+		return managed.ConnectionDetails{}
+	}
+	conn := db.Connection
+	if p != nil && do.StringValue(p.Endpoint) == v1alpha1.EndpointPrivate && db.PrivateConnection != nil {
+		conn = db.PrivateConnection
+	}
+	if conn == nil {
+		return managed.ConnectionDetails{}
+	}
+
+	uri := conn.URI
+	if name := targetDatabase(p, db.EngineSlug); name != "" {
+		uri = withDatabase(uri, name)
+	}
+	if p != nil && p.SSLMode != nil {
+		uri = withSSLMode(uri, db.EngineSlug, *p.SSLMode)
+	}
+
+	cd := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(uri),
+		"host":                                    []byte(conn.Host),
+		xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(conn.Port)),
+		xpv1.ResourceCredentialsSecretUserKey:     []byte(conn.User),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte(conn.Password),
+	}
+
+	// MongoDB clusters are reached through a mongodb+srv:// URI that carries
+	// no port, and clients need to know which database to authenticate
+	// against, so we publish it alongside the SRV URI.
+	if db.EngineSlug == v1alpha1.EngineMongoDB {
+		cd["database"] = []byte(conn.Database)
+	}
+	if name := targetDatabase(p, db.EngineSlug); name != "" {
+		cd["database"] = []byte(name)
+	}
+	if VerifyFull(p) && len(ca) > 0 {
+		cd[ConnectionSecretCAKey] = ca
+	}
+	if p != nil {
+		database := conn.Database
+		if name := targetDatabase(p, db.EngineSlug); name != "" {
+			database = name
+		}
+		for _, t := range p.Templates {
+			cd[t.Key] = []byte(renderConnectionTemplate(t.Template, conn, database))
+		}
+		for _, k := range p.OmitKeys {
+			delete(cd, k)
+		}
+	}
+	return cd
+}
+
+var (
+	connectionTemplateKeyRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+	connectionTemplateRegexp    = regexp.MustCompile(`^([^{}]|\{(host|port|user|password|database)\})*$`)
+)
+
+const (
+	errTemplateKey = "invalid connection template key %q: must consist of alphanumeric characters, '-', '_' or '.'"
+	errTemplate    = "invalid connection template for key %q: only the placeholders {host}, {port}, {user}, {password} and {database} may be enclosed in braces"
+)
+
+// ValidateConnectionTemplates returns an error if any of the connection
+// templates of the supplied connection parameters has an invalid key or uses
+// an unknown placeholder. The same rules are enforced at admission.
+func ValidateConnectionTemplates(p *v1alpha1.DODatabaseClusterConnectionParameters) error {
+	if p == nil {
+		return nil
+	}
+	for _, t := range p.Templates {
+		if !connectionTemplateKeyRegexp.MatchString(t.Key) {
+			return errors.Errorf(errTemplateKey, t.Key)
+		}
+		if t.Template == "" || !connectionTemplateRegexp.MatchString(t.Template) {
+			return errors.Errorf(errTemplate, t.Key)
+		}
+	}
+	return nil
+}
+
+// renderConnectionTemplate replaces the placeholders of the supplied
+// connection template with the supplied connection details.
+func renderConnectionTemplate(template string, conn *godo.DatabaseConnection, database string) string {
+	return strings.NewReplacer(
+		"{host}", conn.Host,
+		"{port}", strconv.Itoa(conn.Port),
+		"{user}", conn.User,
+		"{password}", conn.Password,
+		"{database}", database,
+	).Replace(template)
+}
+
+// VerifyFull returns true if the supplied connection parameters request that
+// the server certificate is verified against the cluster's CA.
+func VerifyFull(p *v1alpha1.DODatabaseClusterConnectionParameters) bool {
+	return p != nil && do.StringValue(p.SSLMode) == v1alpha1.SSLModeVerifyFull
+}
+
+// targetDatabase returns the logical database the connection URI should
+// target, or an empty string if the cluster's default database should be used.
+// Only PostgreSQL and MySQL URIs name their database in the path.
+func targetDatabase(p *v1alpha1.DODatabaseClusterConnectionParameters, engine string) string {
+	if p == nil {
+		return ""
+	}
+	switch engine {
+	case v1alpha1.EnginePostgreSQL, v1alpha1.EngineMySQL:
+		return do.StringValue(p.Database)
+	}
+	return ""
+}
+
+// withDatabase replaces the database in the path of the supplied URI, keeping
+// its SSL query parameters, which differ between PostgreSQL and MySQL.
+func withDatabase(uri, name string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	u.Path = "/" + name
+	u.RawPath = ""
+	return u.String()
+}
+
+// mysqlSSLModes maps the SSL modes of a connection to the ssl-mode query
+// parameter of MySQL URIs. Verifying the hostname is what PostgreSQL's
+// verify-full does on top of verifying the certificate.
+var mysqlSSLModes = map[string]string{
+	v1alpha1.SSLModeDisable:    "DISABLED",
+	v1alpha1.SSLModeRequire:    "REQUIRED",
+	v1alpha1.SSLModeVerifyFull: "VERIFY_IDENTITY",
+}
+
+// withSSLMode rewrites the SSL mode of the supplied connection URI to the
+// supplied one, which is validated at admission. URIs of engines without an
+// SSL mode query parameter are returned unchanged.
+func withSSLMode(uri, engine, mode string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	q := u.Query()
+	switch engine {
+	case v1alpha1.EnginePostgreSQL:
+		q.Set("sslmode", mode)
+	case v1alpha1.EngineMySQL:
+		m, ok := mysqlSSLModes[mode]
+		if !ok {
+			return uri
+		}
+		q.Set("ssl-mode", m)
+	default:
+		return uri
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// MaxDatabaseNameLength is the longest logical database name that is accepted
+// by all engines an initial database can be created for.
+const MaxDatabaseNameLength = 63
+
+var databaseNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+const (
+	errInitialDBEngine = "an initial database can't be created for a %q cluster"
+	errInitialDBName   = "initial database name %q must start with a letter or underscore, contain only letters, digits, underscores and dashes, and be at most %d characters long"
+)
+
+// ValidateInitialDatabaseName returns an error if an initial database name is
+// specified for an engine that doesn't support logical databases, or if the
+// name isn't accepted by DigitalOcean.
+func ValidateInitialDatabaseName(p v1alpha1.DODatabaseClusterParameters, engine string) error {
+	if p.InitialDatabaseName == nil {
+		return nil
+	}
+	name := *p.InitialDatabaseName
+	if engine != v1alpha1.EnginePostgreSQL && engine != v1alpha1.EngineMySQL {
+		return errors.Errorf(errInitialDBEngine, engine)
+	}
+	if len(name) > MaxDatabaseNameLength || !databaseNameRegexp.MatchString(name) {
+		return errors.Errorf(errInitialDBName, name, MaxDatabaseNameLength)
+	}
+	return nil
+}
+
+// NeedsInitialDatabase returns true if an initial database is requested but
+// doesn't exist in the cluster yet. DigitalOcean can't create it along with
+// the cluster, so it is only created once the cluster is online.
+func NeedsInitialDatabase(p v1alpha1.DODatabaseClusterParameters, o v1alpha1.DODatabaseClusterObservation) bool {
+	if p.InitialDatabaseName == nil || o.Status != v1alpha1.StatusOnline {
+		return false
+	}
+	return !contains(o.DbNames, *p.InitialDatabaseName)
+}
+
+// ConnectionParameters returns the connection parameters of the supplied
+// DODatabaseClusterParameters. The connection targets the initial database
+// unless another database is specified explicitly, and uses the private
+// endpoint if public access is disabled.
+func ConnectionParameters(p v1alpha1.DODatabaseClusterParameters) *v1alpha1.DODatabaseClusterConnectionParameters {
+	initial := p.InitialDatabaseName != nil && (p.Connection == nil || p.Connection.Database == nil)
+	private := p.PublicAccess != nil && !*p.PublicAccess
+	if !initial && !private {
+		return p.Connection
+	}
+	conn := &v1alpha1.DODatabaseClusterConnectionParameters{}
+	if p.Connection != nil {
+		conn = p.Connection.DeepCopy()
+	}
+	if initial {
+		conn.Database = p.InitialDatabaseName
+	}
+	if private {
+		conn.Endpoint = godo.String(v1alpha1.EndpointPrivate)
+	}
+	return conn
 }
 
 // LateInitializeSpec updates any unset (i.e. nil) optional fields of the
@@ -44,3 +560,45 @@ func LateInitializeSpec(p *v1alpha1.DODatabaseClusterParameters, observed godo.D
 		copy(p.Tags, observed.Tags)
 	}
 }
+
+// GetPausedConfig returns the PausedConfig recorded on the supplied
+// DODatabaseCluster, or nil if it is not paused.
+func GetPausedConfig(cr *v1alpha1.DODatabaseCluster) (*PausedConfig, error) {
+	v, ok := cr.GetAnnotations()[AnnotationPausedConfig]
+	if !ok {
+		return nil, nil
+	}
+	pc := &PausedConfig{}
+	if err := json.Unmarshal([]byte(v), pc); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+// SetPausedConfig records the supplied PausedConfig on the supplied
+// DODatabaseCluster.
+func SetPausedConfig(cr *v1alpha1.DODatabaseCluster, pc PausedConfig) error {
+	b, err := json.Marshal(pc)
+	if err != nil {
+		return err
+	}
+	meta.AddAnnotations(cr, map[string]string{AnnotationPausedConfig: string(b)})
+	return nil
+}
+
+// IsPauseUpToDate returns true if the observed node count of the supplied
+// DODatabaseCluster matches its paused state. A single node cluster is at its
+// paused size, as that is never smaller than its current size. Clusters that
+// are still resizing are considered up to date so that they are not resized
+// again.
+func IsPauseUpToDate(cr *v1alpha1.DODatabaseCluster) bool {
+	o := cr.Status.AtProvider
+	if o.Status == v1alpha1.StatusResizing {
+		return true
+	}
+	_, recorded := cr.GetAnnotations()[AnnotationPausedConfig]
+	if do.BoolValue(cr.Spec.ForProvider.Paused) {
+		return recorded && o.NumNodes == PausedNumNodes
+	}
+	return !recorded
+}
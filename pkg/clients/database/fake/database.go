@@ -0,0 +1,123 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+)
+
+// this ensures that the mock implements the client interface
+var _ database.DatabaseClient = (*MockDatabaseClient)(nil)
+
+// MockDatabaseClient is a type that implements all the methods for DatabaseClient interface
+type MockDatabaseClient struct {
+	MockGet         func(context.Context, string) (*godo.Database, *godo.Response, error)
+	MockList        func(context.Context, *godo.ListOptions) ([]godo.Database, *godo.Response, error)
+	MockListOptions func(context.Context) (*godo.DatabaseOptions, *godo.Response, error)
+	MockGetCA       func(context.Context, string) (*godo.DatabaseCA, *godo.Response, error)
+
+	MockGetFirewallRules    func(context.Context, string) ([]godo.DatabaseFirewallRule, *godo.Response, error)
+	MockUpdateFirewallRules func(context.Context, string, *godo.DatabaseUpdateFirewallRulesRequest) (*godo.Response, error)
+
+	MockCreate   func(context.Context, *godo.DatabaseCreateRequest) (*godo.Database, *godo.Response, error)
+	MockCreateDB func(context.Context, string, *godo.DatabaseCreateDBRequest) (*godo.DatabaseDB, *godo.Response, error)
+	MockDelete   func(context.Context, string) (*godo.Response, error)
+	MockResize   func(context.Context, string, *godo.DatabaseResizeRequest) (*godo.Response, error)
+
+	MockListReplicas func(context.Context, string, *godo.ListOptions) ([]godo.DatabaseReplica, *godo.Response, error)
+
+	MockCreateUser func(context.Context, string, *godo.DatabaseCreateUserRequest) (*godo.DatabaseUser, *godo.Response, error)
+	MockDeleteUser func(context.Context, string, string) (*godo.Response, error)
+
+	MockGetPostgreSQLConfig    func(context.Context, string) (*godo.PostgreSQLConfig, *godo.Response, error)
+	MockUpdatePostgreSQLConfig func(context.Context, string, *godo.PostgreSQLConfig) (*godo.Response, error)
+	MockGetRedisConfig         func(context.Context, string) (*godo.RedisConfig, *godo.Response, error)
+	MockUpdateRedisConfig      func(context.Context, string, *godo.RedisConfig) (*godo.Response, error)
+}
+
+// Get mocks Get method
+func (c *MockDatabaseClient) Get(ctx context.Context, id string) (*godo.Database, *godo.Response, error) {
+	return c.MockGet(ctx, id)
+}
+
+// List mocks List method
+func (c *MockDatabaseClient) List(ctx context.Context, opt *godo.ListOptions) ([]godo.Database, *godo.Response, error) {
+	return c.MockList(ctx, opt)
+}
+
+// ListOptions mocks ListOptions method
+func (c *MockDatabaseClient) ListOptions(ctx context.Context) (*godo.DatabaseOptions, *godo.Response, error) {
+	return c.MockListOptions(ctx)
+}
+
+// GetCA mocks GetCA method
+func (c *MockDatabaseClient) GetCA(ctx context.Context, id string) (*godo.DatabaseCA, *godo.Response, error) {
+	return c.MockGetCA(ctx, id)
+}
+
+// GetFirewallRules mocks GetFirewallRules method
+func (c *MockDatabaseClient) GetFirewallRules(ctx context.Context, id string) ([]godo.DatabaseFirewallRule, *godo.Response, error) {
+	return c.MockGetFirewallRules(ctx, id)
+}
+
+// UpdateFirewallRules mocks UpdateFirewallRules method
+func (c *MockDatabaseClient) UpdateFirewallRules(ctx context.Context, id string, request *godo.DatabaseUpdateFirewallRulesRequest) (*godo.Response, error) {
+	return c.MockUpdateFirewallRules(ctx, id, request)
+}
+
+// Create mocks Create method
+func (c *MockDatabaseClient) Create(ctx context.Context, request *godo.DatabaseCreateRequest) (*godo.Database, *godo.Response, error) {
+	return c.MockCreate(ctx, request)
+}
+
+// CreateDB mocks CreateDB method
+func (c *MockDatabaseClient) CreateDB(ctx context.Context, id string, request *godo.DatabaseCreateDBRequest) (*godo.DatabaseDB, *godo.Response, error) {
+	return c.MockCreateDB(ctx, id, request)
+}
+
+// ListReplicas mocks ListReplicas method
+func (c *MockDatabaseClient) ListReplicas(ctx context.Context, id string, opt *godo.ListOptions) ([]godo.DatabaseReplica, *godo.Response, error) {
+	return c.MockListReplicas(ctx, id, opt)
+}
+
+// CreateUser mocks CreateUser method
+func (c *MockDatabaseClient) CreateUser(ctx context.Context, id string, request *godo.DatabaseCreateUserRequest) (*godo.DatabaseUser, *godo.Response, error) {
+	return c.MockCreateUser(ctx, id, request)
+}
+
+// DeleteUser mocks DeleteUser method
+func (c *MockDatabaseClient) DeleteUser(ctx context.Context, id, user string) (*godo.Response, error) {
+	return c.MockDeleteUser(ctx, id, user)
+}
+
+// Delete mocks Delete method
+func (c *MockDatabaseClient) Delete(ctx context.Context, id string) (*godo.Response, error) {
+	return c.MockDelete(ctx, id)
+}
+
+// Resize mocks Resize method
+func (c *MockDatabaseClient) Resize(ctx context.Context, id string, request *godo.DatabaseResizeRequest) (*godo.Response, error) {
+	return c.MockResize(ctx, id, request)
+}
+
+// GetPostgreSQLConfig mocks GetPostgreSQLConfig method
+func (c *MockDatabaseClient) GetPostgreSQLConfig(ctx context.Context, id string) (*godo.PostgreSQLConfig, *godo.Response, error) {
+	return c.MockGetPostgreSQLConfig(ctx, id)
+}
+
+// UpdatePostgreSQLConfig mocks UpdatePostgreSQLConfig method
+func (c *MockDatabaseClient) UpdatePostgreSQLConfig(ctx context.Context, id string, config *godo.PostgreSQLConfig) (*godo.Response, error) {
+	return c.MockUpdatePostgreSQLConfig(ctx, id, config)
+}
+
+// GetRedisConfig mocks GetRedisConfig method
+func (c *MockDatabaseClient) GetRedisConfig(ctx context.Context, id string) (*godo.RedisConfig, *godo.Response, error) {
+	return c.MockGetRedisConfig(ctx, id)
+}
+
+// UpdateRedisConfig mocks UpdateRedisConfig method
+func (c *MockDatabaseClient) UpdateRedisConfig(ctx context.Context, id string, config *godo.RedisConfig) (*godo.Response, error) {
+	return c.MockUpdateRedisConfig(ctx, id, config)
+}
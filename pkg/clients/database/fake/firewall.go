@@ -0,0 +1,22 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+)
+
+// this ensures that the mock implements the client interface
+var _ database.VPCGetter = (*MockVPCGetter)(nil)
+
+// MockVPCGetter is a type that implements all the methods for VPCGetter interface
+type MockVPCGetter struct {
+	MockGet func(context.Context, string) (*godo.VPC, *godo.Response, error)
+}
+
+// Get mocks Get method
+func (c *MockVPCGetter) Get(ctx context.Context, id string) (*godo.VPC, *godo.Response, error) {
+	return c.MockGet(ctx, id)
+}
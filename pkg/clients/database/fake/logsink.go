@@ -0,0 +1,40 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+)
+
+// this ensures that the mock implements the client interface
+var _ database.LogsinkClient = (*MockLogsinkClient)(nil)
+
+// MockLogsinkClient is a type that implements all the methods for LogsinkClient interface
+type MockLogsinkClient struct {
+	MockListLogsinks  func(context.Context, string) ([]database.Logsink, *godo.Response, error)
+	MockCreateLogsink func(context.Context, string, *database.Logsink) (*database.Logsink, *godo.Response, error)
+	MockUpdateLogsink func(context.Context, string, string, *database.LogsinkUpdateRequest) (*godo.Response, error)
+	MockDeleteLogsink func(context.Context, string, string) (*godo.Response, error)
+}
+
+// ListLogsinks mocks ListLogsinks method
+func (c *MockLogsinkClient) ListLogsinks(ctx context.Context, clusterID string) ([]database.Logsink, *godo.Response, error) {
+	return c.MockListLogsinks(ctx, clusterID)
+}
+
+// CreateLogsink mocks CreateLogsink method
+func (c *MockLogsinkClient) CreateLogsink(ctx context.Context, clusterID string, ls *database.Logsink) (*database.Logsink, *godo.Response, error) {
+	return c.MockCreateLogsink(ctx, clusterID, ls)
+}
+
+// UpdateLogsink mocks UpdateLogsink method
+func (c *MockLogsinkClient) UpdateLogsink(ctx context.Context, clusterID, id string, ur *database.LogsinkUpdateRequest) (*godo.Response, error) {
+	return c.MockUpdateLogsink(ctx, clusterID, id, ur)
+}
+
+// DeleteLogsink mocks DeleteLogsink method
+func (c *MockLogsinkClient) DeleteLogsink(ctx context.Context, clusterID, id string) (*godo.Response, error) {
+	return c.MockDeleteLogsink(ctx, clusterID, id)
+}
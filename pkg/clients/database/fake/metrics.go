@@ -0,0 +1,28 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+)
+
+// this ensures that the mock implements the client interface
+var _ database.MetricsClient = (*MockMetricsClient)(nil)
+
+// MockMetricsClient is a type that implements all the methods for MetricsClient interface
+type MockMetricsClient struct {
+	MockGetMetricsCredentials func(context.Context) (*database.MetricsCredentials, *godo.Response, error)
+	MockGetMetricsEndpoints   func(context.Context, string) ([]database.MetricsEndpoint, *godo.Response, error)
+}
+
+// GetMetricsCredentials mocks GetMetricsCredentials method
+func (c *MockMetricsClient) GetMetricsCredentials(ctx context.Context) (*database.MetricsCredentials, *godo.Response, error) {
+	return c.MockGetMetricsCredentials(ctx)
+}
+
+// GetMetricsEndpoints mocks GetMetricsEndpoints method
+func (c *MockMetricsClient) GetMetricsEndpoints(ctx context.Context, id string) ([]database.MetricsEndpoint, *godo.Response, error) {
+	return c.MockGetMetricsEndpoints(ctx, id)
+}
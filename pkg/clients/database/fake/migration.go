@@ -0,0 +1,28 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+)
+
+// this ensures that the mock implements the client interface
+var _ database.MigrationClient = (*MockMigrationClient)(nil)
+
+// MockMigrationClient is a type that implements all the methods for MigrationClient interface
+type MockMigrationClient struct {
+	MockStartOnlineMigration     func(context.Context, string, *database.OnlineMigrationRequest) (*database.OnlineMigrationStatus, *godo.Response, error)
+	MockGetOnlineMigrationStatus func(context.Context, string) (*database.OnlineMigrationStatus, *godo.Response, error)
+}
+
+// StartOnlineMigration mocks StartOnlineMigration method
+func (c *MockMigrationClient) StartOnlineMigration(ctx context.Context, id string, request *database.OnlineMigrationRequest) (*database.OnlineMigrationStatus, *godo.Response, error) {
+	return c.MockStartOnlineMigration(ctx, id, request)
+}
+
+// GetOnlineMigrationStatus mocks GetOnlineMigrationStatus method
+func (c *MockMigrationClient) GetOnlineMigrationStatus(ctx context.Context, id string) (*database.OnlineMigrationStatus, *godo.Response, error) {
+	return c.MockGetOnlineMigrationStatus(ctx, id)
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	dofake "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const (
+	databasesPath = "/v2/databases"
+	databasePath  = databasesPath + "/" + dofake.Wildcard
+
+	errNotFound  = "database cluster %s not found"
+	errNameInUse = "a database cluster named %s already exists"
+)
+
+// The bodies of the requests and responses of the database endpoints. godo
+// doesn't export them.
+type databaseRoot struct {
+	Database *godo.Database `json:"database"`
+}
+
+type databasesRoot struct {
+	Databases []godo.Database `json:"databases"`
+}
+
+type databaseDBRoot struct {
+	DB *godo.DatabaseDB `json:"db"`
+}
+
+type databaseReplicasRoot struct {
+	Replicas []godo.DatabaseReplica `json:"replicas"`
+}
+
+type databaseFirewallRulesRoot struct {
+	Rules []godo.DatabaseFirewallRule `json:"rules"`
+}
+
+// A DatabaseServer is a fake DigitalOcean API that keeps Database Clusters in
+// memory. Like DigitalOcean, it rejects clusters whose name is already in
+// use. It supports creating, getting, listing, resizing and deleting
+// clusters as well as creating their databases, listing their replicas and
+// managing their trusted sources. Newly created clusters are creating until
+// SetStatus brings them online. Other endpoints can be added to the embedded
+// Server using Handle.
+type DatabaseServer struct {
+	*dofake.Server
+
+	mu        sync.Mutex
+	created   int
+	databases map[string]*godo.Database
+	firewalls map[string][]godo.DatabaseFirewallRule
+	replicas  map[string][]godo.DatabaseReplica
+}
+
+// NewDatabaseServer starts and returns a new DatabaseServer without any
+// clusters. Callers should Close it when they are done.
+func NewDatabaseServer() *DatabaseServer {
+	s := &DatabaseServer{
+		Server:    dofake.NewServer(),
+		databases: map[string]*godo.Database{},
+		firewalls: map[string][]godo.DatabaseFirewallRule{},
+		replicas:  map[string][]godo.DatabaseReplica{},
+	}
+	s.Handle(http.MethodGet, databasesPath, s.list)
+	s.Handle(http.MethodPost, databasesPath, s.create)
+	s.Handle(http.MethodGet, databasePath, s.get)
+	s.Handle(http.MethodDelete, databasePath, s.delete)
+	s.Handle(http.MethodPut, databasePath+"/resize", s.resize)
+	s.Handle(http.MethodPost, databasePath+"/dbs", s.createDB)
+	s.Handle(http.MethodGet, databasePath+"/replicas", s.listReplicas)
+	s.Handle(http.MethodGet, databasePath+"/firewall", s.getFirewallRules)
+	s.Handle(http.MethodPut, databasePath+"/firewall", s.updateFirewallRules)
+	return s
+}
+
+// SetStatus sets the status of the cluster with the supplied ID, e.g. to
+// bring it online once it was created.
+func (s *DatabaseServer) SetStatus(id, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if db, ok := s.databases[id]; ok {
+		db.Status = status
+	}
+}
+
+// AddReplica adds the supplied read-only replica to the cluster with the
+// supplied ID.
+func (s *DatabaseServer) AddReplica(id string, r godo.DatabaseReplica) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replicas[id] = append(s.replicas[id], r)
+}
+
+// Database returns a copy of the cluster with the supplied ID, or nil if it
+// doesn't exist.
+func (s *DatabaseServer) Database(id string) *godo.Database {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, ok := s.databases[id]
+	if !ok {
+		return nil
+	}
+	cp := *db
+	return &cp
+}
+
+func (s *DatabaseServer) list(r *http.Request, _ []string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	root := databasesRoot{Databases: []godo.Database{}}
+	for _, db := range s.databases {
+		root.Databases = append(root.Databases, *db)
+	}
+	return http.StatusOK, root
+}
+
+func (s *DatabaseServer) create(r *http.Request, _ []string) (int, interface{}) {
+	req := &godo.DatabaseCreateRequest{}
+	if err := dofake.DecodeBody(r, req); err != nil {
+		return dofake.Error(http.StatusBadRequest, err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, db := range s.databases {
+		if db.Name == req.Name {
+			return dofake.Error(http.StatusUnprocessableEntity, fmt.Sprintf(errNameInUse, req.Name))
+		}
+	}
+	s.created++
+	id := fmt.Sprintf("%s-%d", req.Name, s.created)
+	host := req.Name + ".db.ondigitalocean.com"
+	db := &godo.Database{
+		ID:                 id,
+		Name:               req.Name,
+		EngineSlug:         req.EngineSlug,
+		VersionSlug:        req.Version,
+		NumNodes:           req.NumNodes,
+		SizeSlug:           req.SizeSlug,
+		RegionSlug:         req.Region,
+		Status:             v1alpha1.StatusCreating,
+		CreatedAt:          time.Now().UTC().Truncate(time.Second),
+		PrivateNetworkUUID: req.PrivateNetworkUUID,
+		Tags:               req.Tags,
+		DBNames:            []string{"defaultdb"},
+		Connection:         connection(host),
+		PrivateConnection:  connection("private-" + host),
+		MaintenanceWindow:  &godo.DatabaseMaintenanceWindow{Day: "sunday", Hour: "00:00:00"},
+	}
+	s.databases[id] = db
+	return http.StatusCreated, databaseRoot{Database: db}
+}
+
+func (s *DatabaseServer) get(r *http.Request, args []string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, ok := s.databases[args[0]]
+	if !ok {
+		return dofake.Error(http.StatusNotFound, fmt.Sprintf(errNotFound, args[0]))
+	}
+	return http.StatusOK, databaseRoot{Database: db}
+}
+
+func (s *DatabaseServer) delete(r *http.Request, args []string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.databases[args[0]]; !ok {
+		return dofake.Error(http.StatusNotFound, fmt.Sprintf(errNotFound, args[0]))
+	}
+	delete(s.databases, args[0])
+	delete(s.firewalls, args[0])
+	delete(s.replicas, args[0])
+	return http.StatusNoContent, nil
+}
+
+func (s *DatabaseServer) resize(r *http.Request, args []string) (int, interface{}) {
+	req := &godo.DatabaseResizeRequest{}
+	if err := dofake.DecodeBody(r, req); err != nil {
+		return dofake.Error(http.StatusBadRequest, err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, ok := s.databases[args[0]]
+	if !ok {
+		return dofake.Error(http.StatusNotFound, fmt.Sprintf(errNotFound, args[0]))
+	}
+	db.SizeSlug = req.SizeSlug
+	db.NumNodes = req.NumNodes
+	return http.StatusAccepted, nil
+}
+
+func (s *DatabaseServer) createDB(r *http.Request, args []string) (int, interface{}) {
+	req := &godo.DatabaseCreateDBRequest{}
+	if err := dofake.DecodeBody(r, req); err != nil {
+		return dofake.Error(http.StatusBadRequest, err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, ok := s.databases[args[0]]
+	if !ok {
+		return dofake.Error(http.StatusNotFound, fmt.Sprintf(errNotFound, args[0]))
+	}
+	db.DBNames = append(db.DBNames, req.Name)
+	return http.StatusCreated, databaseDBRoot{DB: &godo.DatabaseDB{Name: req.Name}}
+}
+
+func (s *DatabaseServer) listReplicas(r *http.Request, args []string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.databases[args[0]]; !ok {
+		return dofake.Error(http.StatusNotFound, fmt.Sprintf(errNotFound, args[0]))
+	}
+	return http.StatusOK, databaseReplicasRoot{Replicas: s.replicas[args[0]]}
+}
+
+func (s *DatabaseServer) getFirewallRules(r *http.Request, args []string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.databases[args[0]]; !ok {
+		return dofake.Error(http.StatusNotFound, fmt.Sprintf(errNotFound, args[0]))
+	}
+	return http.StatusOK, databaseFirewallRulesRoot{Rules: s.firewalls[args[0]]}
+}
+
+func (s *DatabaseServer) updateFirewallRules(r *http.Request, args []string) (int, interface{}) {
+	req := &godo.DatabaseUpdateFirewallRulesRequest{}
+	if err := dofake.DecodeBody(r, req); err != nil {
+		return dofake.Error(http.StatusBadRequest, err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.databases[args[0]]; !ok {
+		return dofake.Error(http.StatusNotFound, fmt.Sprintf(errNotFound, args[0]))
+	}
+	rules := make([]godo.DatabaseFirewallRule, len(req.Rules))
+	for i, rule := range req.Rules {
+		rules[i] = godo.DatabaseFirewallRule{ClusterUUID: args[0], Type: rule.Type, Value: rule.Value}
+	}
+	s.firewalls[args[0]] = rules
+	return http.StatusNoContent, nil
+}
+
+func connection(host string) *godo.DatabaseConnection {
+	return &godo.DatabaseConnection{
+		URI:      fmt.Sprintf("postgresql://doadmin:password@%s:25060/defaultdb?sslmode=require", host),
+		Database: "defaultdb",
+		Host:     host,
+		Port:     25060,
+		User:     "doadmin",
+		Password: "password",
+		SSL:      true,
+	}
+}
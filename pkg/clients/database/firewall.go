@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"sort"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	// FirewallRuleTypeIPAddr is the type of trusted source that allows an IP
+	// address or CIDR range to connect to a Database Cluster.
+	FirewallRuleTypeIPAddr = "ip_addr"
+
+	// FirewallRuleTypeTag is the type of trusted source that allows the
+	// Droplets with a tag to connect to a Database Cluster.
+	FirewallRuleTypeTag = "tag"
+
+	// AnnotationManagedTrustedDropletTags records the Droplet tags the
+	// provider applied as trusted sources of a DODatabaseCluster, so that
+	// their trusted sources are removed once no tags are desired anymore.
+	AnnotationManagedTrustedDropletTags = "database.do.crossplane.io/managed-trusted-droplet-tags"
+)
+
+// VPCGetter gets VPCs.
+type VPCGetter interface {
+	Get(context.Context, string) (*godo.VPC, *godo.Response, error)
+}
+
+// HasVPCRule returns true if the supplied trusted sources include the one
+// that allows connections from the supplied VPC IP range.
+func HasVPCRule(rules []godo.DatabaseFirewallRule, ipRange string) bool {
+	for _, r := range rules {
+		if r.Type == FirewallRuleTypeIPAddr && r.Value == ipRange {
+			return true
+		}
+	}
+	return false
+}
+
+// WithVPCRule returns the supplied trusted sources with the one that allows
+// connections from the supplied VPC IP range added or removed. All other
+// trusted sources are kept.
+func WithVPCRule(rules []godo.DatabaseFirewallRule, ipRange string, present bool) []*godo.DatabaseFirewallRule {
+	out := []*godo.DatabaseFirewallRule{}
+	for _, r := range rules {
+		if r.Type != FirewallRuleTypeIPAddr || r.Value != ipRange {
+			out = append(out, &godo.DatabaseFirewallRule{Type: r.Type, Value: r.Value})
+		}
+	}
+	if present {
+		out = append(out, &godo.DatabaseFirewallRule{Type: FirewallRuleTypeIPAddr, Value: ipRange})
+	}
+	return out
+}
+
+// IsPublicAccessUpToDate returns true if the observed public access of the
+// cluster matches the desired one, or if either of them is unknown.
+func IsPublicAccessUpToDate(p v1alpha1.DODatabaseClusterParameters, o v1alpha1.DODatabaseClusterObservation) bool {
+	if p.PublicAccess == nil || o.PublicAccess == nil {
+		return true
+	}
+	return *p.PublicAccess == *o.PublicAccess
+}
+
+// TagRules returns the trusted sources that allow the Droplets with any of
+// the supplied tags to connect to a Database Cluster, ordered by tag.
+func TagRules(tags []string) []*godo.DatabaseFirewallRule {
+	rules := make([]*godo.DatabaseFirewallRule, 0, len(tags))
+	for _, t := range uniqueSorted(tags) {
+		rules = append(rules, &godo.DatabaseFirewallRule{Type: FirewallRuleTypeTag, Value: t})
+	}
+	return rules
+}
+
+// TrustedDropletTags returns the tags of the Droplets the supplied trusted
+// sources allow to connect, sorted and without duplicates.
+func TrustedDropletTags(rules []godo.DatabaseFirewallRule) []string {
+	var tags []string
+	for _, r := range rules {
+		if r.Type == FirewallRuleTypeTag {
+			tags = append(tags, r.Value)
+		}
+	}
+	return uniqueSorted(tags)
+}
+
+// WithTagRules returns the supplied trusted sources with those of type tag
+// replaced by the ones for the supplied tags.
+func WithTagRules(rules []godo.DatabaseFirewallRule, tags []string) []*godo.DatabaseFirewallRule {
+	out := []*godo.DatabaseFirewallRule{}
+	for _, r := range rules {
+		if r.Type != FirewallRuleTypeTag {
+			out = append(out, &godo.DatabaseFirewallRule{Type: r.Type, Value: r.Value})
+		}
+	}
+	return append(out, TagRules(tags)...)
+}
+
+// GetManagedTrustedDropletTags returns the Droplet tags the provider last
+// applied as trusted sources of the supplied cluster.
+func GetManagedTrustedDropletTags(cr *v1alpha1.DODatabaseCluster) []string {
+	return do.GetTagsAnnotation(cr, AnnotationManagedTrustedDropletTags)
+}
+
+// SetManagedTrustedDropletTags records the Droplet tags the provider applied
+// as trusted sources of the supplied cluster.
+func SetManagedTrustedDropletTags(cr *v1alpha1.DODatabaseCluster, tags []string) {
+	do.SetTagsAnnotation(cr, AnnotationManagedTrustedDropletTags, uniqueSorted(tags))
+}
+
+// ManagesTrustedDropletTags returns true if the trusted sources of type tag
+// of the supplied cluster are managed, i.e. if Droplet tags are desired or
+// were applied before and may have to be removed.
+func ManagesTrustedDropletTags(cr *v1alpha1.DODatabaseCluster) bool {
+	return len(cr.Spec.ForProvider.TrustedDropletTags) > 0 || len(GetManagedTrustedDropletTags(cr)) > 0
+}
+
+// AreTrustedDropletTagsUpToDate returns true if the Droplet tags the observed
+// trusted sources of the cluster allow to connect are the desired ones, and
+// are recorded as applied. Trusted sources are only observed once the cluster
+// is online.
+func AreTrustedDropletTagsUpToDate(cr *v1alpha1.DODatabaseCluster) bool {
+	if !ManagesTrustedDropletTags(cr) || cr.Status.AtProvider.Status != v1alpha1.StatusOnline {
+		return true
+	}
+	want := uniqueSorted(cr.Spec.ForProvider.TrustedDropletTags)
+	return equal(want, cr.Status.AtProvider.TrustedDropletTags) && equal(want, uniqueSorted(GetManagedTrustedDropletTags(cr)))
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func uniqueSorted(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(s))
+	out := make([]string, 0, len(s))
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+)
+
+func TestTagRules(t *testing.T) {
+	cases := map[string]struct {
+		tags []string
+		want []*godo.DatabaseFirewallRule
+	}{
+		"NoTags": {
+			want: []*godo.DatabaseFirewallRule{},
+		},
+		"SortedAndUnique": {
+			tags: []string{"web", "app", "web"},
+			want: []*godo.DatabaseFirewallRule{
+				{Type: FirewallRuleTypeTag, Value: "app"},
+				{Type: FirewallRuleTypeTag, Value: "web"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, TagRules(tc.tags)); diff != "" {
+				t.Errorf("TagRules(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithTagRules(t *testing.T) {
+	observed := []godo.DatabaseFirewallRule{
+		{UUID: "1", ClusterUUID: "db", Type: FirewallRuleTypeIPAddr, Value: "10.10.0.0/20"},
+		{UUID: "2", ClusterUUID: "db", Type: FirewallRuleTypeTag, Value: "old"},
+		{UUID: "3", ClusterUUID: "db", Type: "droplet", Value: "163973392"},
+	}
+	want := []*godo.DatabaseFirewallRule{
+		{Type: FirewallRuleTypeIPAddr, Value: "10.10.0.0/20"},
+		{Type: "droplet", Value: "163973392"},
+		{Type: FirewallRuleTypeTag, Value: "web"},
+	}
+	if diff := cmp.Diff(want, WithTagRules(observed, []string{"web"})); diff != "" {
+		t.Errorf("WithTagRules(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestAreTrustedDropletTagsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		p       v1alpha1.DODatabaseClusterParameters
+		o       v1alpha1.DODatabaseClusterObservation
+		managed string
+		want    bool
+	}{
+		"Unmanaged": {
+			o:    v1alpha1.DODatabaseClusterObservation{Status: v1alpha1.StatusOnline, TrustedDropletTags: []string{"web"}},
+			want: true,
+		},
+		"NotOnline": {
+			p:    v1alpha1.DODatabaseClusterParameters{TrustedDropletTags: []string{"web"}},
+			o:    v1alpha1.DODatabaseClusterObservation{Status: v1alpha1.StatusCreating},
+			want: true,
+		},
+		"UpToDate": {
+			p:       v1alpha1.DODatabaseClusterParameters{TrustedDropletTags: []string{"web", "app", "web"}},
+			o:       v1alpha1.DODatabaseClusterObservation{Status: v1alpha1.StatusOnline, TrustedDropletTags: []string{"app", "web"}},
+			managed: "app,web",
+			want:    true,
+		},
+		"NotRecorded": {
+			p: v1alpha1.DODatabaseClusterParameters{TrustedDropletTags: []string{"web"}},
+			o: v1alpha1.DODatabaseClusterObservation{Status: v1alpha1.StatusOnline, TrustedDropletTags: []string{"web"}},
+		},
+		"Missing": {
+			p:       v1alpha1.DODatabaseClusterParameters{TrustedDropletTags: []string{"web", "app"}},
+			o:       v1alpha1.DODatabaseClusterObservation{Status: v1alpha1.StatusOnline, TrustedDropletTags: []string{"web"}},
+			managed: "web",
+		},
+		"Extra": {
+			p:       v1alpha1.DODatabaseClusterParameters{TrustedDropletTags: []string{"web"}},
+			o:       v1alpha1.DODatabaseClusterObservation{Status: v1alpha1.StatusOnline, TrustedDropletTags: []string{"old", "web"}},
+			managed: "old,web",
+		},
+		"AllRemoved": {
+			o:       v1alpha1.DODatabaseClusterObservation{Status: v1alpha1.StatusOnline, TrustedDropletTags: []string{"web"}},
+			managed: "web",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DODatabaseCluster{
+				Spec:   v1alpha1.DODatabaseClusterSpec{ForProvider: tc.p},
+				Status: v1alpha1.DODatabaseClusterStatus{AtProvider: tc.o},
+			}
+			if tc.managed != "" {
+				cr.SetAnnotations(map[string]string{AnnotationManagedTrustedDropletTags: tc.managed})
+			}
+			if got := AreTrustedDropletTagsUpToDate(cr); got != tc.want {
+				t.Errorf("AreTrustedDropletTagsUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWithVPCRule(t *testing.T) {
+	vpc := "10.10.0.0/20"
+	others := []godo.DatabaseFirewallRule{
+		{UUID: "1", Type: FirewallRuleTypeIPAddr, Value: "203.0.113.1"},
+		{UUID: "2", Type: FirewallRuleTypeTag, Value: "web"},
+	}
+	withVPC := append([]godo.DatabaseFirewallRule{{UUID: "3", Type: FirewallRuleTypeIPAddr, Value: vpc}}, others...)
+
+	cases := map[string]struct {
+		rules   []godo.DatabaseFirewallRule
+		present bool
+		want    []*godo.DatabaseFirewallRule
+	}{
+		"AddsVPCRule": {
+			rules:   others,
+			present: true,
+			want: []*godo.DatabaseFirewallRule{
+				{Type: FirewallRuleTypeIPAddr, Value: "203.0.113.1"},
+				{Type: FirewallRuleTypeTag, Value: "web"},
+				{Type: FirewallRuleTypeIPAddr, Value: vpc},
+			},
+		},
+		"KeepsSingleVPCRule": {
+			rules:   withVPC,
+			present: true,
+			want: []*godo.DatabaseFirewallRule{
+				{Type: FirewallRuleTypeIPAddr, Value: "203.0.113.1"},
+				{Type: FirewallRuleTypeTag, Value: "web"},
+				{Type: FirewallRuleTypeIPAddr, Value: vpc},
+			},
+		},
+		"RemovesVPCRule": {
+			rules: withVPC,
+			want: []*godo.DatabaseFirewallRule{
+				{Type: FirewallRuleTypeIPAddr, Value: "203.0.113.1"},
+				{Type: FirewallRuleTypeTag, Value: "web"},
+			},
+		},
+		"RemovesOnlyRule": {
+			rules: withVPC[:1],
+			want:  []*godo.DatabaseFirewallRule{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := WithVPCRule(tc.rules, vpc, tc.present)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("WithVPCRule(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHasVPCRule(t *testing.T) {
+	rules := []godo.DatabaseFirewallRule{
+		{Type: FirewallRuleTypeIPAddr, Value: "203.0.113.1"},
+		{Type: FirewallRuleTypeTag, Value: "10.10.0.0/20"},
+	}
+	if HasVPCRule(rules, "10.10.0.0/20") {
+		t.Errorf("HasVPCRule(...): want false, got true")
+	}
+	rules = append(rules, godo.DatabaseFirewallRule{Type: FirewallRuleTypeIPAddr, Value: "10.10.0.0/20"})
+	if !HasVPCRule(rules, "10.10.0.0/20") {
+		t.Errorf("HasVPCRule(...): want true, got false")
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	logsinksPath = "/v2/databases/%s/logsink"
+	logsinkPath  = "/v2/databases/%s/logsink/%s"
+
+	errLogsinkConfigMissing = "%s configuration is required for a %q logsink"
+	errLogsinkConfigExtra   = "%s configuration can't be set for a %q logsink"
+)
+
+// LogsinkConfig is the type specific configuration of a logsink. Only the
+// fields of the logsink's type are set.
+type LogsinkConfig struct {
+	// rsyslog
+	Server  string `json:"server,omitempty"`
+	Port    int    `json:"port,omitempty"`
+	TLS     bool   `json:"tls,omitempty"`
+	Format  string `json:"format,omitempty"`
+	Logline string `json:"logline,omitempty"`
+	SD      string `json:"sd,omitempty"`
+	Cert    string `json:"cert,omitempty"`
+	Key     string `json:"key,omitempty"`
+
+	// elasticsearch and opensearch
+	URL          string `json:"url,omitempty"`
+	IndexPrefix  string `json:"index_prefix,omitempty"`
+	IndexDaysMax int    `json:"index_days_max,omitempty"`
+	Timeout      int    `json:"timeout,omitempty"`
+
+	CA string `json:"ca,omitempty"`
+}
+
+// Logsink is a logsink of a Database Cluster.
+type Logsink struct {
+	ID     string         `json:"sink_id,omitempty"`
+	Name   string         `json:"sink_name,omitempty"`
+	Type   string         `json:"sink_type,omitempty"`
+	Config *LogsinkConfig `json:"config,omitempty"`
+}
+
+// LogsinkUpdateRequest is the request body used to update a logsink.
+type LogsinkUpdateRequest struct {
+	Config *LogsinkConfig `json:"config"`
+}
+
+// LogsinkClient is the external client used for DODatabaseLogsink Custom
+// Resource. godo does not support these endpoints yet.
+type LogsinkClient interface {
+	ListLogsinks(context.Context, string) ([]Logsink, *godo.Response, error)
+	CreateLogsink(context.Context, string, *Logsink) (*Logsink, *godo.Response, error)
+	UpdateLogsink(context.Context, string, string, *LogsinkUpdateRequest) (*godo.Response, error)
+	DeleteLogsink(context.Context, string, string) (*godo.Response, error)
+}
+
+// NewLogsinkClient returns a LogsinkClient that issues requests through the
+// supplied godo.Client.
+func NewLogsinkClient(c *godo.Client) LogsinkClient {
+	return &logsinkClient{client: c}
+}
+
+type logsinkClient struct {
+	client *godo.Client
+}
+
+func (c *logsinkClient) ListLogsinks(ctx context.Context, clusterID string) ([]Logsink, *godo.Response, error) {
+	root := new(struct {
+		Sinks []Logsink `json:"sinks"`
+	})
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf(logsinksPath, clusterID), nil, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Sinks, resp, nil
+}
+
+func (c *logsinkClient) CreateLogsink(ctx context.Context, clusterID string, ls *Logsink) (*Logsink, *godo.Response, error) {
+	root := new(struct {
+		Sink *Logsink `json:"sink"`
+	})
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf(logsinksPath, clusterID), ls, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Sink, resp, nil
+}
+
+func (c *logsinkClient) UpdateLogsink(ctx context.Context, clusterID, id string, ur *LogsinkUpdateRequest) (*godo.Response, error) {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf(logsinkPath, clusterID, id), ur, nil)
+}
+
+func (c *logsinkClient) DeleteLogsink(ctx context.Context, clusterID, id string) (*godo.Response, error) {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf(logsinkPath, clusterID, id), nil, nil)
+}
+
+func (c *logsinkClient) do(ctx context.Context, method, path string, body, v interface{}) (*godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(ctx, req, v)
+}
+
+// LogsinkSecrets are the secret values of a logsink's configuration.
+type LogsinkSecrets struct {
+	// Key is the private key of the rsyslog client certificate.
+	Key string
+	// URL is the URL of the Elasticsearch or OpenSearch cluster.
+	URL string
+}
+
+// ValidateLogsink returns an error if the supplied DODatabaseLogsinkParameters
+// don't configure exactly the logsink type they specify.
+func ValidateLogsink(p v1alpha1.DODatabaseLogsinkParameters) error {
+	switch p.Type {
+	case v1alpha1.LogsinkTypeRsyslog:
+		if p.Rsyslog == nil {
+			return errors.Errorf(errLogsinkConfigMissing, "rsyslog", p.Type)
+		}
+		if p.Elasticsearch != nil {
+			return errors.Errorf(errLogsinkConfigExtra, "elasticsearch", p.Type)
+		}
+	case v1alpha1.LogsinkTypeElasticsearch, v1alpha1.LogsinkTypeOpenSearch:
+		if p.Elasticsearch == nil {
+			return errors.Errorf(errLogsinkConfigMissing, "elasticsearch", p.Type)
+		}
+		if p.Rsyslog != nil {
+			return errors.Errorf(errLogsinkConfigExtra, "rsyslog", p.Type)
+		}
+	}
+	return nil
+}
+
+// GenerateLogsinkConfig generates *LogsinkConfig instance from
+// DODatabaseLogsinkParameters and the secret values it references.
+func GenerateLogsinkConfig(p v1alpha1.DODatabaseLogsinkParameters, s LogsinkSecrets) *LogsinkConfig {
+	cfg := &LogsinkConfig{}
+	if r := p.Rsyslog; r != nil {
+		cfg.Server = r.Server
+		cfg.Port = r.Port
+		cfg.TLS = do.BoolValue(r.TLS)
+		cfg.Format = r.Format
+		cfg.Logline = do.StringValue(r.Logline)
+		cfg.SD = do.StringValue(r.SD)
+		cfg.CA = do.StringValue(r.CA)
+		cfg.Cert = do.StringValue(r.Cert)
+		cfg.Key = s.Key
+	}
+	if e := p.Elasticsearch; e != nil {
+		cfg.URL = s.URL
+		cfg.IndexPrefix = e.IndexPrefix
+		cfg.IndexDaysMax = do.IntValue(e.IndexDaysMax)
+		cfg.Timeout = do.IntValue(e.Timeout)
+		cfg.CA = do.StringValue(e.CA)
+	}
+	return cfg
+}
+
+// IsLogsinkUpToDate returns true if the configuration of the supplied
+// observed logsink matches DODatabaseLogsinkParameters. Optional fields that
+// aren't specified keep whatever value DigitalOcean chose, and secret values
+// aren't compared, as DigitalOcean doesn't return them.
+func IsLogsinkUpToDate(p v1alpha1.DODatabaseLogsinkParameters, observed Logsink) bool {
+	if observed.Config == nil {
+		return false
+	}
+	got := *observed.Config
+	got.Key, got.URL = "", ""
+
+	want := GenerateLogsinkConfig(p, LogsinkSecrets{})
+	if r := p.Rsyslog; r != nil {
+		if r.TLS == nil {
+			want.TLS = got.TLS
+		}
+		if r.Logline == nil {
+			want.Logline = got.Logline
+		}
+		if r.SD == nil {
+			want.SD = got.SD
+		}
+		if r.CA == nil {
+			want.CA = got.CA
+		}
+		if r.Cert == nil {
+			want.Cert = got.Cert
+		}
+	}
+	if e := p.Elasticsearch; e != nil {
+		if e.IndexDaysMax == nil {
+			want.IndexDaysMax = got.IndexDaysMax
+		}
+		if e.Timeout == nil {
+			want.Timeout = got.Timeout
+		}
+		if e.CA == nil {
+			want.CA = got.CA
+		}
+	}
+	return *want == got
+}
+
+// FindLogsink returns the logsink with the supplied name, or nil if none of
+// the supplied logsinks has that name.
+func FindLogsink(sinks []Logsink, name string) *Logsink {
+	for i := range sinks {
+		if sinks[i].Name == name {
+			return &sinks[i]
+		}
+	}
+	return nil
+}
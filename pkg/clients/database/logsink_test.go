@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+)
+
+func TestValidateLogsink(t *testing.T) {
+	rsyslog := &v1alpha1.DODatabaseLogsinkRsyslogConfig{Server: "logs.example.com", Port: 514, Format: "rfc5424"}
+	es := &v1alpha1.DODatabaseLogsinkElasticsearchConfig{IndexPrefix: "db"}
+
+	cases := map[string]struct {
+		p    v1alpha1.DODatabaseLogsinkParameters
+		want error
+	}{
+		"Rsyslog": {
+			p: v1alpha1.DODatabaseLogsinkParameters{Type: v1alpha1.LogsinkTypeRsyslog, Rsyslog: rsyslog},
+		},
+		"OpenSearchUsesElasticsearchConfig": {
+			p: v1alpha1.DODatabaseLogsinkParameters{Type: v1alpha1.LogsinkTypeOpenSearch, Elasticsearch: es},
+		},
+		"MissingConfig": {
+			p:    v1alpha1.DODatabaseLogsinkParameters{Type: v1alpha1.LogsinkTypeElasticsearch},
+			want: errors.Errorf(errLogsinkConfigMissing, "elasticsearch", v1alpha1.LogsinkTypeElasticsearch),
+		},
+		"ConfigOfOtherType": {
+			p:    v1alpha1.DODatabaseLogsinkParameters{Type: v1alpha1.LogsinkTypeRsyslog, Rsyslog: rsyslog, Elasticsearch: es},
+			want: errors.Errorf(errLogsinkConfigExtra, "elasticsearch", v1alpha1.LogsinkTypeRsyslog),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateLogsink(tc.p)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateLogsink(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsLogsinkUpToDate(t *testing.T) {
+	p := v1alpha1.DODatabaseLogsinkParameters{
+		Type: v1alpha1.LogsinkTypeRsyslog,
+		Rsyslog: &v1alpha1.DODatabaseLogsinkRsyslogConfig{
+			Server: "logs.example.com",
+			Port:   514,
+			TLS:    godo.PtrTo(true),
+			Format: "rfc5424",
+		},
+	}
+
+	cases := map[string]struct {
+		observed Logsink
+		want     bool
+	}{
+		"UpToDate": {
+			observed: Logsink{Config: &LogsinkConfig{Server: "logs.example.com", Port: 514, TLS: true, Format: "rfc5424"}},
+			want:     true,
+		},
+		"IgnoresUnsetFieldsAndSecrets": {
+			observed: Logsink{Config: &LogsinkConfig{Server: "logs.example.com", Port: 514, TLS: true, Format: "rfc5424", SD: "sd", Key: "redacted"}},
+			want:     true,
+		},
+		"PortDrifted": {
+			observed: Logsink{Config: &LogsinkConfig{Server: "logs.example.com", Port: 6514, TLS: true, Format: "rfc5424"}},
+		},
+		"TLSDisabled": {
+			observed: Logsink{Config: &LogsinkConfig{Server: "logs.example.com", Port: 514, Format: "rfc5424"}},
+		},
+		"NoConfig": {
+			observed: Logsink{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsLogsinkUpToDate(p, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsLogsinkUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+const (
+	metricsCredentialsPath = "/v2/databases/metrics/credentials"
+	databasePath           = "/v2/databases/%s"
+
+	// MetricsSecretEndpointsKey is the key of the metrics secret that holds
+	// the comma separated host:port pairs of every metrics endpoint.
+	MetricsSecretEndpointsKey = "endpoints"
+)
+
+// MetricsCredentials are the basic auth credentials of the metrics endpoints
+// of all Database Clusters of an account.
+type MetricsCredentials struct {
+	Username string `json:"basic_auth_username"`
+	Password string `json:"basic_auth_password"`
+}
+
+// MetricsEndpoint is an endpoint that serves the metrics of a Database
+// Cluster.
+type MetricsEndpoint struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// MetricsClient is the external client used to get the metrics credentials
+// and endpoints of a DODatabaseCluster. godo does not support these endpoints
+// yet.
+type MetricsClient interface {
+	GetMetricsCredentials(context.Context) (*MetricsCredentials, *godo.Response, error)
+	GetMetricsEndpoints(context.Context, string) ([]MetricsEndpoint, *godo.Response, error)
+}
+
+// NewMetricsClient returns a MetricsClient that issues requests through the
+// supplied godo.Client.
+func NewMetricsClient(c *godo.Client) MetricsClient {
+	return &metricsClient{client: c}
+}
+
+type metricsClient struct {
+	client *godo.Client
+}
+
+func (c *metricsClient) GetMetricsCredentials(ctx context.Context) (*MetricsCredentials, *godo.Response, error) {
+	root := new(struct {
+		Credentials *MetricsCredentials `json:"credentials"`
+	})
+	resp, err := c.get(ctx, metricsCredentialsPath, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Credentials, resp, nil
+}
+
+// GetMetricsEndpoints gets the Database Cluster, as godo doesn't decode its
+// metrics endpoints.
+func (c *metricsClient) GetMetricsEndpoints(ctx context.Context, id string) ([]MetricsEndpoint, *godo.Response, error) {
+	root := new(struct {
+		Database struct {
+			MetricsEndpoints []MetricsEndpoint `json:"metrics_endpoints"`
+		} `json:"database"`
+	})
+	resp, err := c.get(ctx, fmt.Sprintf(databasePath, id), root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Database.MetricsEndpoints, resp, nil
+}
+
+func (c *metricsClient) get(ctx context.Context, path string, v interface{}) (*godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(ctx, req, v)
+}
+
+// GenerateMetricsDetails generates the contents of the metrics secret of a
+// Database Cluster. The "endpoint" and "port" keys hold the first metrics
+// endpoint. Keys of which DigitalOcean returned no value are omitted, e.g. the
+// endpoints of an engine that doesn't expose metrics.
+func GenerateMetricsDetails(creds *MetricsCredentials, endpoints []MetricsEndpoint) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if creds != nil && creds.Username != "" {
+		cd[xpv1.ResourceCredentialsSecretUserKey] = []byte(creds.Username)
+		cd[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(creds.Password)
+	}
+	if len(endpoints) == 0 {
+		return cd
+	}
+	cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(endpoints[0].Host)
+	cd[xpv1.ResourceCredentialsSecretPortKey] = []byte(strconv.Itoa(endpoints[0].Port))
+	all := make([]string, len(endpoints))
+	for i, e := range endpoints {
+		all[i] = net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+	}
+	cd[MetricsSecretEndpointsKey] = []byte(strings.Join(all, ","))
+	return cd
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const onlineMigrationPath = "/v2/databases/%s/online-migration"
+
+// OnlineMigrationSource is the source database of an online migration.
+type OnlineMigrationSource struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	DBName   string `json:"dbname,omitempty"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// OnlineMigrationRequest is the request body used to start an online
+// migration.
+type OnlineMigrationRequest struct {
+	Source     *OnlineMigrationSource `json:"source"`
+	DisableSSL bool                   `json:"disable_ssl,omitempty"`
+	IgnoreDBs  []string               `json:"ignore_dbs,omitempty"`
+}
+
+// OnlineMigrationStatus is the status of an online migration.
+type OnlineMigrationStatus struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+}
+
+// MigrationClient is the external client used to manage the online migration
+// of a DODatabaseCluster. godo does not support these endpoints yet.
+type MigrationClient interface {
+	StartOnlineMigration(context.Context, string, *OnlineMigrationRequest) (*OnlineMigrationStatus, *godo.Response, error)
+	GetOnlineMigrationStatus(context.Context, string) (*OnlineMigrationStatus, *godo.Response, error)
+}
+
+// NewMigrationClient returns a MigrationClient that issues requests through
+// the supplied godo.Client.
+func NewMigrationClient(c *godo.Client) MigrationClient {
+	return &migrationClient{client: c}
+}
+
+type migrationClient struct {
+	client *godo.Client
+}
+
+func (c *migrationClient) StartOnlineMigration(ctx context.Context, id string, mr *OnlineMigrationRequest) (*OnlineMigrationStatus, *godo.Response, error) {
+	return c.do(ctx, http.MethodPut, id, mr)
+}
+
+func (c *migrationClient) GetOnlineMigrationStatus(ctx context.Context, id string) (*OnlineMigrationStatus, *godo.Response, error) {
+	return c.do(ctx, http.MethodGet, id, nil)
+}
+
+func (c *migrationClient) do(ctx context.Context, method, id string, body interface{}) (*OnlineMigrationStatus, *godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, method, fmt.Sprintf(onlineMigrationPath, id), body)
+	if err != nil {
+		return nil, nil, err
+	}
+	status := new(OnlineMigrationStatus)
+	resp, err := c.client.Do(ctx, req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+	return status, resp, nil
+}
+
+// GenerateOnlineMigration generates *OnlineMigrationRequest instance from
+// DODatabaseClusterOnlineMigrationParameters and the source password.
+func GenerateOnlineMigration(in v1alpha1.DODatabaseClusterOnlineMigrationParameters, password string) *OnlineMigrationRequest {
+	return &OnlineMigrationRequest{
+		Source: &OnlineMigrationSource{
+			Host:     in.Source.Host,
+			Port:     in.Source.Port,
+			DBName:   do.StringValue(in.Source.DBName),
+			Username: in.Source.Username,
+			Password: password,
+		},
+		DisableSSL: do.BoolValue(in.DisableSSL),
+		IgnoreDBs:  in.IgnoreDBs,
+	}
+}
+
+// NeedsOnlineMigration returns true if an online migration is requested but
+// has never been started. Migrations are one-shot: once any migration has been
+// observed it is never restarted, regardless of how it finished.
+func NeedsOnlineMigration(p v1alpha1.DODatabaseClusterParameters, o v1alpha1.DODatabaseClusterObservation) bool {
+	return p.OnlineMigration != nil && o.Status == v1alpha1.StatusOnline && o.OnlineMigration.ID == ""
+}
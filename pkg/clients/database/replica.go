@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"sort"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// MaxReplicaRegions is the largest number of replica regions that are
+// reported in the status of a Database Cluster.
+const MaxReplicaRegions = 10
+
+// ReplicaLister lists the read-only replicas of Database Clusters.
+type ReplicaLister interface {
+	ListReplicas(context.Context, string, *godo.ListOptions) ([]godo.DatabaseReplica, *godo.Response, error)
+}
+
+// SupportsReplicas returns true if clusters of the supplied engine can have
+// read-only replicas.
+func SupportsReplicas(engine string) bool {
+	return engine == v1alpha1.EnginePostgreSQL || engine == v1alpha1.EngineMySQL
+}
+
+// ListReplicas returns every read-only replica of the Database Cluster with
+// the supplied ID.
+func ListReplicas(ctx context.Context, c ReplicaLister, id string) ([]godo.DatabaseReplica, error) {
+	var replicas []godo.DatabaseReplica
+	opt := &godo.ListOptions{PerPage: do.MaxListPerPage}
+	for {
+		page, resp, err := c.ListReplicas(ctx, id, opt)
+		if err != nil {
+			return nil, err
+		}
+		replicas = append(replicas, page...)
+		more, err := do.NextPage(resp, opt)
+		if err != nil || !more {
+			return replicas, err
+		}
+	}
+}
+
+// GenerateReplicaObservation returns whether any of the supplied replicas is
+// in another region than the supplied one, and the regions of the replicas.
+// The regions are sorted, without duplicates and capped at MaxReplicaRegions,
+// so that the status of the cluster doesn't change with the listing order.
+func GenerateReplicaObservation(region string, replicas []godo.DatabaseReplica) (bool, []string) {
+	crossRegion := false
+	seen := map[string]bool{}
+	var regions []string
+	for _, r := range replicas {
+		if r.Region != region {
+			crossRegion = true
+		}
+		if !seen[r.Region] {
+			seen[r.Region] = true
+			regions = append(regions, r.Region)
+		}
+	}
+	sort.Strings(regions)
+	if len(regions) > MaxReplicaRegions {
+		regions = regions[:MaxReplicaRegions]
+	}
+	return crossRegion, regions
+}
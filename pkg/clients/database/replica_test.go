@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateReplicaObservation(t *testing.T) {
+	var many []godo.DatabaseReplica
+	var manyRegions []string
+	for i := MaxReplicaRegions + 2; i > 0; i-- {
+		many = append(many, godo.DatabaseReplica{Region: fmt.Sprintf("region-%02d", i)})
+	}
+	for i := 1; i <= MaxReplicaRegions; i++ {
+		manyRegions = append(manyRegions, fmt.Sprintf("region-%02d", i))
+	}
+
+	type want struct {
+		crossRegion bool
+		regions     []string
+	}
+	cases := map[string]struct {
+		replicas []godo.DatabaseReplica
+		want     want
+	}{
+		"NoReplicas": {},
+		"SameRegion": {
+			replicas: []godo.DatabaseReplica{{Name: "a", Region: "nyc1"}, {Name: "b", Region: "nyc1"}},
+			want:     want{regions: []string{"nyc1"}},
+		},
+		"CrossRegion": {
+			replicas: []godo.DatabaseReplica{{Name: "a", Region: "fra1"}, {Name: "b", Region: "ams3"}},
+			want:     want{crossRegion: true, regions: []string{"ams3", "fra1"}},
+		},
+		"Capped": {
+			replicas: many,
+			want:     want{crossRegion: true, regions: manyRegions},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			crossRegion, regions := GenerateReplicaObservation("nyc1", tc.replicas)
+			if diff := cmp.Diff(tc.want, want{crossRegion: crossRegion, regions: regions}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("GenerateReplicaObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
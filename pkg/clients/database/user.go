@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+)
+
+const (
+	// AdminUser is the admin user DigitalOcean creates along with every
+	// Database Cluster. It is never deleted.
+	AdminUser = "doadmin"
+
+	// UserRolePrimary is the role of the admin user of a Database Cluster.
+	UserRolePrimary = "primary"
+)
+
+// UserPasswordKey returns the connection secret key the password of the
+// supplied user is written to.
+func UserPasswordKey(name string) string {
+	return name + ".password"
+}
+
+// DiffUsers returns the users that need to be created and the names of the
+// users that need to be deleted for the observed users of a cluster to match
+// the desired ones. Users aren't managed unless at least one is desired, and
+// the admin user is never deleted.
+func DiffUsers(desired []v1alpha1.DODatabaseClusterUserParameters, observed []v1alpha1.DODatabaseClusterUser) ([]*godo.DatabaseCreateUserRequest, []string) {
+	if len(desired) == 0 {
+		return nil, nil
+	}
+
+	exists := make(map[string]bool, len(observed))
+	for _, u := range observed {
+		exists[u.Name] = true
+	}
+	wanted := make(map[string]bool, len(desired))
+	var create []*godo.DatabaseCreateUserRequest
+	for _, u := range desired {
+		wanted[u.Name] = true
+		if exists[u.Name] || u.Name == AdminUser {
+			continue
+		}
+		req := &godo.DatabaseCreateUserRequest{Name: u.Name}
+		if u.MySQLSettings != nil {
+			req.MySQLSettings = &godo.DatabaseMySQLUserSettings{AuthPlugin: u.MySQLSettings.AuthPlugin}
+		}
+		create = append(create, req)
+		// Users that are listed more than once are only created once.
+		exists[u.Name] = true
+	}
+
+	var remove []string
+	for _, u := range observed {
+		if wanted[u.Name] || u.Name == AdminUser || u.Role == UserRolePrimary {
+			continue
+		}
+		remove = append(remove, u.Name)
+	}
+	return create, remove
+}
+
+// AreUsersUpToDate returns true if the observed users of the cluster match
+// the desired ones. Users are only observed once the cluster is online.
+func AreUsersUpToDate(p v1alpha1.DODatabaseClusterParameters, o v1alpha1.DODatabaseClusterObservation) bool {
+	if o.Status != v1alpha1.StatusOnline {
+		return true
+	}
+	create, remove := DiffUsers(p.Users, o.Users)
+	return len(create) == 0 && len(remove) == 0
+}
+
+// GenerateUserConnectionDetails returns the passwords of the desired users
+// that were observed, keyed by UserPasswordKey.
+func GenerateUserConnectionDetails(desired []v1alpha1.DODatabaseClusterUserParameters, observed []v1alpha1.DODatabaseClusterUser) managed.ConnectionDetails {
+	passwords := make(map[string]string, len(observed))
+	for _, u := range observed {
+		passwords[u.Name] = u.Password
+	}
+	cd := managed.ConnectionDetails{}
+	for _, u := range desired {
+		if pw, ok := passwords[u.Name]; ok && pw != "" {
+			cd[UserPasswordKey(u.Name)] = []byte(pw)
+		}
+	}
+	return cd
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+)
+
+func TestDiffUsers(t *testing.T) {
+	admin := v1alpha1.DODatabaseClusterUser{Name: AdminUser, Role: UserRolePrimary, Password: "admin"}
+	app := v1alpha1.DODatabaseClusterUser{Name: "app", Role: "normal", Password: "secret"}
+
+	type want struct {
+		create []*godo.DatabaseCreateUserRequest
+		remove []string
+	}
+	cases := map[string]struct {
+		desired  []v1alpha1.DODatabaseClusterUserParameters
+		observed []v1alpha1.DODatabaseClusterUser
+		want     want
+	}{
+		"Unmanaged": {
+			observed: []v1alpha1.DODatabaseClusterUser{admin, app},
+		},
+		"UpToDate": {
+			desired:  []v1alpha1.DODatabaseClusterUserParameters{{Name: "app"}},
+			observed: []v1alpha1.DODatabaseClusterUser{admin, app},
+		},
+		"Add": {
+			desired: []v1alpha1.DODatabaseClusterUserParameters{
+				{Name: "app"},
+				{Name: "reports", MySQLSettings: &v1alpha1.DODatabaseUserMySQLSettings{AuthPlugin: "mysql_native_password"}},
+				{Name: "reports"},
+			},
+			observed: []v1alpha1.DODatabaseClusterUser{admin, app},
+			want: want{create: []*godo.DatabaseCreateUserRequest{
+				{Name: "reports", MySQLSettings: &godo.DatabaseMySQLUserSettings{AuthPlugin: "mysql_native_password"}},
+			}},
+		},
+		"Remove": {
+			desired:  []v1alpha1.DODatabaseClusterUserParameters{{Name: "reports"}},
+			observed: []v1alpha1.DODatabaseClusterUser{admin, app, {Name: "reports"}},
+			want:     want{remove: []string{"app"}},
+		},
+		"NeverRemovesAdmin": {
+			desired:  []v1alpha1.DODatabaseClusterUserParameters{{Name: "app"}},
+			observed: []v1alpha1.DODatabaseClusterUser{{Name: AdminUser}, app},
+		},
+		"NeverCreatesAdmin": {
+			desired:  []v1alpha1.DODatabaseClusterUserParameters{{Name: AdminUser}},
+			observed: []v1alpha1.DODatabaseClusterUser{app},
+			want:     want{remove: []string{"app"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			create, remove := DiffUsers(tc.desired, tc.observed)
+			if diff := cmp.Diff(tc.want, want{create: create, remove: remove}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("DiffUsers(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUserConnectionDetails(t *testing.T) {
+	desired := []v1alpha1.DODatabaseClusterUserParameters{{Name: "app"}, {Name: "reports"}}
+	observed := []v1alpha1.DODatabaseClusterUser{
+		{Name: AdminUser, Password: "admin"},
+		{Name: "app", Password: "secret"},
+	}
+	want := managed.ConnectionDetails{"app.password": []byte("secret")}
+	if diff := cmp.Diff(want, GenerateUserConnectionDetails(desired, observed)); diff != "" {
+		t.Errorf("GenerateUserConnectionDetails(...): -want, +got:\n%s", diff)
+	}
+}
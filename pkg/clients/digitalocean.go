@@ -18,13 +18,24 @@ package clients
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/digitalocean/godo"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/oauth2"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -32,37 +43,289 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/version"
 )
 
+// AnnotationKeyPaused is the annotation that pauses reconciliation of a
+// managed resource when set to "true".
+const AnnotationKeyPaused = "crossplane.io/paused"
+
+// AnnotationKeyAdopt is the annotation that lets a managed resource without
+// an external name adopt an existing external resource when set to
+// AdoptByName. By default a managed resource only ever observes the external
+// resource whose ID is its external name, and creates a new one otherwise.
+//
+// Adopting by name trades safety for convenience: any external resource with
+// the same name is taken over, including one that is managed by someone else
+// or by another managed resource, and is deleted with the managed resource
+// unless its deletion policy is Orphan. Only opt in when names are known to be
+// unique to this managed resource, e.g. to take over resources that were
+// created before the provider managed them.
+const AnnotationKeyAdopt = "do.crossplane.io/adopt"
+
+// AdoptByName is the value of AnnotationKeyAdopt that adopts an existing
+// external resource with the same name as the managed resource.
+const AdoptByName = "by-name"
+
+// MaxListPerPage is the largest page size the DigitalOcean API accepts when
+// listing resources.
+const MaxListPerPage = 200
+
+const (
+	errNegativeAPITimeout    = "apiTimeoutSeconds must not be negative"
+	errNegativeAPIMaxRetries = "apiMaxRetries must not be negative"
+	errInvalidProxyURL       = "proxyURL must be an absolute http or https URL"
+	errGetCACert             = "cannot get the CA certificates secret"
+	errInvalidCACert         = "CA certificates secret key does not contain PEM encoded certificates"
+	errRegionNotAllowed      = "region %q is not allowed by the ProviderConfig, which only allows %s"
+
+	errUnsupportedCredentialsSource = "unsupported credentials source %q"
+	errNoCredentialsSecretRef       = "no credentials secret reference was provided"
+	errNoCredentialsFs              = "no credentials file path was provided"
+	errReadCredentialsFile          = "cannot read the credentials file"
+	errNoCredentialsEnv             = "no credentials environment variable name was provided"
+	errCredentialsEnvUnset          = "credentials environment variable %q is not set"
+)
+
+// UserAgent is the user agent this provider identifies itself with when
+// calling the DigitalOcean API.
+var UserAgent = "crossplane-provider-digitalocean/" + version.Version
+
+// NewClient returns a godo.Client that authenticates with the supplied token
+// and identifies itself with UserAgent. Any additional options are applied
+// after the user agent has been set.
+func NewClient(token string, opts ...godo.ClientOpt) (*godo.Client, error) {
+	return newClient(context.Background(), token, 0, opts...)
+}
+
+func newClient(ctx context.Context, token string, timeout time.Duration, opts ...godo.ClientOpt) (*godo.Client, error) {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: strings.Trim(strings.TrimSpace(token), "'")})
+	hc := oauth2.NewClient(ctx, ts)
+	hc.Timeout = timeout
+	opts = append([]godo.ClientOpt{godo.SetUserAgent(UserAgent)}, opts...)
+	return godo.New(hc, opts...)
+}
+
+// Connect returns a godo.Client for the ProviderConfig of the supplied
+// managed resource. The client authenticates with the credentials of the
+// ProviderConfig and applies its API timeout and retries.
+func Connect(ctx context.Context, c client.Client, mg resource.Managed) (*godo.Client, error) {
+	client, _, err := ConnectWithConfig(ctx, c, mg)
+	return client, err
+}
+
+// ConnectWithConfig is like Connect, but also returns the spec of the
+// ProviderConfig, for controllers that enforce its constraints, e.g. the
+// allowed regions.
+func ConnectWithConfig(ctx context.Context, c client.Client, mg resource.Managed) (*godo.Client, v1alpha1.ProviderConfigSpec, error) {
+	pc, token, err := getProviderConfig(ctx, c, mg)
+	if err != nil {
+		return nil, v1alpha1.ProviderConfigSpec{}, err
+	}
+	if err := validateProviderConfig(pc.Spec); err != nil {
+		return nil, v1alpha1.ProviderConfigSpec{}, err
+	}
+
+	var caCert []byte
+	if ref := pc.Spec.CACertSecretRef; ref != nil {
+		s := &v1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+			return nil, v1alpha1.ProviderConfigSpec{}, errors.Wrap(err, errGetCACert)
+		}
+		caCert = s.Data[ref.Key]
+	}
+	t, err := transports.get(pc, caCert)
+	if err != nil {
+		return nil, v1alpha1.ProviderConfigSpec{}, err
+	}
+
+	// The retries happen below the OAuth transport, which adds the token to
+	// every attempt, and the rate limit is recorded for every attempt.
+	rt := newRetryTransport(newRateLimitTransport(t, pc.GetName()), IntValue(pc.Spec.APIMaxRetries))
+	base := &http.Client{Transport: rt}
+	timeout := time.Duration(IntValue(pc.Spec.APITimeoutSeconds)) * time.Second
+	client, err := newClient(context.WithValue(context.Background(), oauth2.HTTPClient, base), token, timeout)
+	return client, pc.Spec, err
+}
+
+func validateProviderConfig(s v1alpha1.ProviderConfigSpec) error {
+	if IntValue(s.APITimeoutSeconds) < 0 {
+		return errors.New(errNegativeAPITimeout)
+	}
+	if IntValue(s.APIMaxRetries) < 0 {
+		return errors.New(errNegativeAPIMaxRetries)
+	}
+	if s.ProxyURL != nil {
+		u, err := url.Parse(*s.ProxyURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New(errInvalidProxyURL)
+		}
+	}
+	return nil
+}
+
+// ValidateRegion returns an error if the supplied region is not one of the
+// supplied allowed regions. All regions are allowed if none are.
+func ValidateRegion(region string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, r := range allowed {
+		if r == region {
+			return nil
+		}
+	}
+	return errors.Errorf(errRegionNotAllowed, region, strings.Join(allowed, ", "))
+}
+
+// transports caches the transports of the ProviderConfigs that need their
+// own, so that their connections are reused across reconciles.
+var transports = &transportCache{transports: map[string]cachedTransport{}}
+
+// A transportCache holds one transport per ProviderConfig. It is safe for
+// concurrent use.
+type transportCache struct {
+	mu         sync.Mutex
+	transports map[string]cachedTransport
+}
+
+type cachedTransport struct {
+	version   string
+	hash      string
+	transport *http.Transport
+}
+
+// get returns http.DefaultTransport if the supplied ProviderConfig sets
+// neither a proxy nor CA certificates. Otherwise it returns the transport
+// cached for the ProviderConfig, which is replaced when the ProviderConfig or
+// the supplied CA certificates change.
+func (c *transportCache) get(pc *v1alpha1.ProviderConfig, caCert []byte) (http.RoundTripper, error) {
+	if pc.Spec.ProxyURL == nil && pc.Spec.CACertSecretRef == nil {
+		return http.DefaultTransport, nil
+	}
+	h := transportHash(pc.Spec, caCert)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.transports[pc.GetName()]
+	if ok && cached.version == pc.GetResourceVersion() && cached.hash == h {
+		return cached.transport, nil
+	}
+	t, err := newTransport(pc.Spec, caCert)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		cached.transport.CloseIdleConnections()
+	}
+	c.transports[pc.GetName()] = cachedTransport{version: pc.GetResourceVersion(), hash: h, transport: t}
+	return t, nil
+}
+
+// transportHash returns a hash of the settings newTransport configures a
+// transport with.
+func transportHash(s v1alpha1.ProviderConfigSpec, caCert []byte) string {
+	h := sha256.New()
+	h.Write([]byte(StringValue(s.ProxyURL)))
+	h.Write([]byte{0})
+	h.Write(caCert)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newTransport returns a copy of http.DefaultTransport that sends requests
+// through the proxy of the supplied ProviderConfig, falling back to the proxy
+// of the environment, and that trusts the supplied PEM encoded CA
+// certificates in addition to the system ones if the ProviderConfig
+// references any. The ProviderConfig must be valid.
+func newTransport(s v1alpha1.ProviderConfigSpec, caCert []byte) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if s.ProxyURL != nil {
+		u, err := url.Parse(*s.ProxyURL)
+		if err != nil {
+			return nil, errors.New(errInvalidProxyURL)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+	if s.CACertSecretRef != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New(errInvalidCACert)
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return t, nil
+}
+
 // GetAuthInfo returns the necessary authentication information that is necessary
 // to use when the controller connects to DigitalOcean API in order to reconcile
 // the managed resource.
 func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (token string, err error) {
+	_, token, err = getProviderConfig(ctx, c, mg)
+	return token, err
+}
+
+// getProviderConfig returns the ProviderConfig of the supplied managed resource
+// and the token it authenticates to the DigitalOcean API with.
+func getProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*v1alpha1.ProviderConfig, string, error) {
 	pc := &v1alpha1.ProviderConfig{}
 	t := resource.NewProviderConfigUsageTracker(c, &v1alpha1.ProviderConfigUsage{})
 	if err := t.Track(ctx, mg); err != nil {
-		return "", err
+		return nil, "", err
 	}
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
-		return "", err
-	}
-
-	// NOTE(muvaf): When we implement the workload identity, we will only need to
-	// return a different type of option.ClientOption, which is WithTokenSource().
-	if s := pc.Spec.Credentials.Source; s != xpv1.CredentialsSourceSecret {
-		return "", errors.Errorf("unsupported credentials source %q", s)
+		return nil, "", err
 	}
 
-	ref := pc.Spec.Credentials.SecretRef
-	if ref == nil {
-		return "", errors.New("no credentials secret reference was provided")
+	token, err := getToken(ctx, c, afero.NewOsFs(), pc.Spec.Credentials)
+	if err != nil {
+		return nil, "", err
 	}
+	return pc, token, nil
+}
 
-	s := &v1.Secret{}
-	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
-		return "", err
+// getToken returns the token the supplied credentials authenticate to the
+// DigitalOcean API with. The token is read from a secret unless the
+// credentials specify another source, i.e. a file or an environment variable
+// of the provider. Files are read on every call, so that tokens rotated by
+// e.g. a CSI secret driver are picked up.
+func getToken(ctx context.Context, c client.Client, fs afero.Fs, cr v1alpha1.ProviderCredentials) (string, error) {
+	// NOTE(muvaf): When we implement the workload identity, we will only need to
+	// return a different type of option.ClientOption, which is WithTokenSource().
+	switch cr.Source {
+	case xpv1.CredentialsSourceSecret, "":
+		ref := cr.SecretRef
+		if ref == nil {
+			return "", errors.New(errNoCredentialsSecretRef)
+		}
+		s := &v1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+			return "", err
+		}
+		return string(s.Data[ref.Key]), nil
+	case xpv1.CredentialsSourceFilesystem:
+		if cr.Fs == nil {
+			return "", errors.New(errNoCredentialsFs)
+		}
+		b, err := afero.ReadFile(fs, cr.Fs.Path)
+		if err != nil {
+			return "", errors.Wrap(err, errReadCredentialsFile)
+		}
+		return string(b), nil
+	case xpv1.CredentialsSourceEnvironment:
+		if cr.Env == nil {
+			return "", errors.New(errNoCredentialsEnv)
+		}
+		token, ok := os.LookupEnv(cr.Env.Name)
+		if !ok || token == "" {
+			return "", errors.Errorf(errCredentialsEnvUnset, cr.Env.Name)
+		}
+		return token, nil
+	default:
+		return "", errors.Errorf(errUnsupportedCredentialsSource, cr.Source)
 	}
-	return string(s.Data[ref.Key]), nil
 }
 
 // StringValue converts the supplied string pointer to a string, returning the
@@ -165,3 +428,92 @@ func IgnoreNotFound(err error, response *godo.Response) error {
 	}
 	return err
 }
+
+// IsRetryable returns true if the supplied godo response and error indicate
+// that the DigitalOcean API is temporarily unavailable, for example during
+// platform maintenance. Such requests are expected to succeed when retried.
+func IsRetryable(response *godo.Response, err error) bool {
+	return hasStatusCode(response, err, http.StatusServiceUnavailable)
+}
+
+// IsLocked returns true if the supplied godo response and error indicate
+// that the resource is locked, for example while DigitalOcean maintains it.
+// Such requests are expected to succeed once the lock is released, so callers
+// should retry them later rather than report an error. The response may be
+// nil if only the error was kept.
+func IsLocked(response *godo.Response, err error) bool {
+	return hasStatusCode(response, err, http.StatusLocked)
+}
+
+// IsTimedOut returns true if the supplied error indicates that a request was
+// cancelled because its deadline was exceeded. Such requests may succeed when
+// retried with a fresh deadline.
+func IsTimedOut(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// WithTimeout returns a copy of the supplied context that is cancelled after
+// the supplied timeout. A zero timeout leaves the context as is.
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func hasStatusCode(response *godo.Response, err error, code int) bool {
+	if err == nil {
+		return false
+	}
+	if response != nil && response.Response != nil && response.StatusCode == code {
+		return true
+	}
+	var er *godo.ErrorResponse
+	return errors.As(err, &er) && er.Response != nil && er.Response.StatusCode == code
+}
+
+// NextPage advances the supplied ListOptions to the page after the one the
+// supplied response was for. It returns false once the last page was listed.
+func NextPage(response *godo.Response, opt *godo.ListOptions) (bool, error) {
+	if response == nil || response.Links == nil || response.Links.IsLastPage() {
+		return false, nil
+	}
+	page, err := response.Links.CurrentPage()
+	if err != nil {
+		return false, err
+	}
+	opt.Page = page + 1
+	return true, nil
+}
+
+// IsPaused returns true if reconciliation of the supplied resource is paused
+// using the AnnotationKeyPaused annotation.
+func IsPaused(mg resource.Managed) bool {
+	return mg.GetAnnotations()[AnnotationKeyPaused] == "true"
+}
+
+// TypeDeletionBlocked resources were deleted, but their external resource
+// is not.
+const TypeDeletionBlocked xpv1.ConditionType = "DeletionBlocked"
+
+// ReasonReconcilePaused indicates reconciliation of a resource is paused.
+const ReasonReconcilePaused xpv1.ConditionReason = "ReconcilePaused"
+
+// DeletionPaused returns a condition that indicates the external resource is
+// not deleted because reconciliation of the resource is paused.
+func DeletionPaused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionBlocked,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReconcilePaused,
+		Message:            "reconciliation is paused, remove the " + AnnotationKeyPaused + " annotation to delete the external resource",
+	}
+}
+
+// ShouldAdoptByName returns true if the supplied resource opted in to adopting
+// an existing external resource by name using the AnnotationKeyAdopt
+// annotation.
+func ShouldAdoptByName(mg resource.Managed) bool {
+	return mg.GetAnnotations()[AnnotationKeyAdopt] == AdoptByName
+}
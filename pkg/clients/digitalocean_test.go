@@ -0,0 +1,466 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/version"
+)
+
+func TestNewClientUserAgent(t *testing.T) {
+	c, err := NewClient("token")
+	if err != nil {
+		t.Fatalf("NewClient(...): %v", err)
+	}
+
+	want := "crossplane-provider-digitalocean/" + version.Version
+	if !strings.HasPrefix(c.UserAgent, want+" ") {
+		t.Errorf("NewClient(...): want user agent with prefix %q, got %q", want, c.UserAgent)
+	}
+	if !strings.Contains(c.UserAgent, "godo/") {
+		t.Errorf("NewClient(...): want user agent to keep the godo suffix, got %q", c.UserAgent)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	errBoom := errors.New("boom")
+	resp := func(code int) *godo.Response {
+		return &godo.Response{Response: &http.Response{StatusCode: code}}
+	}
+
+	cases := map[string]struct {
+		response *godo.Response
+		err      error
+		want     bool
+	}{
+		"NoError": {
+			response: resp(http.StatusServiceUnavailable),
+			want:     false,
+		},
+		"InternalServerError": {
+			response: resp(http.StatusInternalServerError),
+			err:      errBoom,
+			want:     false,
+		},
+		"ServiceUnavailable": {
+			response: resp(http.StatusServiceUnavailable),
+			err:      errBoom,
+			want:     true,
+		},
+		"UnprocessableEntity": {
+			response: resp(http.StatusUnprocessableEntity),
+			err:      errBoom,
+			want:     false,
+		},
+		"ServiceUnavailableErrorResponse": {
+			err:  &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}},
+			want: true,
+		},
+		"NilResponse": {
+			err:  errBoom,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsRetryable(tc.response, tc.err); got != tc.want {
+				t.Errorf("IsRetryable(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsLocked(t *testing.T) {
+	errBoom := errors.New("boom")
+	resp := func(code int) *godo.Response {
+		return &godo.Response{Response: &http.Response{StatusCode: code}}
+	}
+
+	cases := map[string]struct {
+		response *godo.Response
+		err      error
+		want     bool
+	}{
+		"NoError": {
+			response: resp(http.StatusLocked),
+			want:     false,
+		},
+		"Locked": {
+			response: resp(http.StatusLocked),
+			err:      errBoom,
+			want:     true,
+		},
+		"WrappedLockedErrorResponse": {
+			err:  errors.Wrap(&godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusLocked}}, "cannot update"),
+			want: true,
+		},
+		"ServiceUnavailable": {
+			response: resp(http.StatusServiceUnavailable),
+			err:      errBoom,
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsLocked(tc.response, tc.err); got != tc.want {
+				t.Errorf("IsLocked(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsTimedOut(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"NoError": {
+			want: false,
+		},
+		"DeadlineExceeded": {
+			err:  errors.Wrap(context.DeadlineExceeded, "cannot create"),
+			want: true,
+		},
+		"Canceled": {
+			err:  context.Canceled,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsTimedOut(tc.err); got != tc.want {
+				t.Errorf("IsTimedOut(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateProviderConfig(t *testing.T) {
+	cases := map[string]struct {
+		spec v1alpha1.ProviderConfigSpec
+		want error
+	}{
+		"Unset": {},
+		"Set": {
+			spec: v1alpha1.ProviderConfigSpec{APITimeoutSeconds: godo.PtrTo(30), APIMaxRetries: godo.PtrTo(0)},
+		},
+		"NegativeTimeout": {
+			spec: v1alpha1.ProviderConfigSpec{APITimeoutSeconds: godo.PtrTo(-1)},
+			want: errors.New(errNegativeAPITimeout),
+		},
+		"NegativeRetries": {
+			spec: v1alpha1.ProviderConfigSpec{APIMaxRetries: godo.PtrTo(-1)},
+			want: errors.New(errNegativeAPIMaxRetries),
+		},
+		"ProxyURL": {
+			spec: v1alpha1.ProviderConfigSpec{ProxyURL: godo.PtrTo("http://proxy.example.com:3128")},
+		},
+		"ProxyURLWithoutScheme": {
+			spec: v1alpha1.ProviderConfigSpec{ProxyURL: godo.PtrTo("proxy.example.com:3128")},
+			want: errors.New(errInvalidProxyURL),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := validateProviderConfig(tc.spec)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("validateProviderConfig(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetAuthInfo(t *testing.T) {
+	secretRef := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "do", Namespace: "crossplane-system"}, Key: "token"}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.ProviderConfigUsage:
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "providerconfigusages"}, key.Name)
+			case *v1alpha1.ProviderConfig:
+				o.Spec.Credentials = v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef}}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"token": []byte("secret-token")}
+			}
+			return nil
+		},
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+	mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+
+	token, err := GetAuthInfo(context.Background(), kube, mg)
+	if err != nil {
+		t.Fatalf("GetAuthInfo(...): %v", err)
+	}
+	if diff := cmp.Diff("secret-token", token); diff != "" {
+		t.Errorf("GetAuthInfo(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGetToken(t *testing.T) {
+	errBoom := errors.New("boom")
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/var/run/secrets/do/token", []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("secret-token")}
+			return nil
+		},
+	}
+	t.Setenv("TEST_DIGITALOCEAN_TOKEN", "env-token")
+	t.Setenv("TEST_DIGITALOCEAN_TOKEN_EMPTY", "")
+	secretRef := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "do", Namespace: "crossplane-system"}, Key: "token"}
+
+	type want struct {
+		token string
+		err   error
+	}
+	cases := map[string]struct {
+		kube client.Client
+		cr   v1alpha1.ProviderCredentials
+		want want
+	}{
+		"Secret": {
+			kube: kube,
+			cr:   v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef}},
+			want: want{token: "secret-token"},
+		},
+		"SecretByDefault": {
+			kube: kube,
+			cr:   v1alpha1.ProviderCredentials{CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef}},
+			want: want{token: "secret-token"},
+		},
+		"NoSecretRef": {
+			cr:   v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret},
+			want: want{err: errors.New(errNoCredentialsSecretRef)},
+		},
+		"GetSecretError": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef}},
+			want: want{err: errBoom},
+		},
+		"Filesystem": {
+			cr: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: "/var/run/secrets/do/token"}},
+			},
+			want: want{token: "file-token\n"},
+		},
+		"NoFilesystemPath": {
+			cr:   v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem},
+			want: want{err: errors.New(errNoCredentialsFs)},
+		},
+		"MissingFile": {
+			cr: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: "/nope"}},
+			},
+			want: want{err: errors.Wrap(errors.New("open /nope: file does not exist"), errReadCredentialsFile)},
+		},
+		"Environment": {
+			cr: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "TEST_DIGITALOCEAN_TOKEN"}},
+			},
+			want: want{token: "env-token"},
+		},
+		"NoEnvironmentVariableName": {
+			cr:   v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment},
+			want: want{err: errors.New(errNoCredentialsEnv)},
+		},
+		"UnsetEnvironmentVariable": {
+			cr: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "TEST_DIGITALOCEAN_TOKEN_UNSET"}},
+			},
+			want: want{err: errors.Errorf(errCredentialsEnvUnset, "TEST_DIGITALOCEAN_TOKEN_UNSET")},
+		},
+		"EmptyEnvironmentVariable": {
+			cr: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "TEST_DIGITALOCEAN_TOKEN_EMPTY"}},
+			},
+			want: want{err: errors.Errorf(errCredentialsEnvUnset, "TEST_DIGITALOCEAN_TOKEN_EMPTY")},
+		},
+		"Unsupported": {
+			cr:   v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity},
+			want: want{err: errors.Errorf(errUnsupportedCredentialsSource, xpv1.CredentialsSourceInjectedIdentity)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			token, err := getToken(context.Background(), tc.kube, fs, tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("getToken(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.token, token); diff != "" {
+				t.Errorf("getToken(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRegion(t *testing.T) {
+	cases := map[string]struct {
+		region  string
+		allowed []string
+		want    error
+	}{
+		"AllAllowed": {
+			region: "nyc1",
+		},
+		"Allowed": {
+			region:  "fra1",
+			allowed: []string{"ams3", "fra1"},
+		},
+		"NotAllowed": {
+			region:  "nyc1",
+			allowed: []string{"ams3", "fra1"},
+			want:    errors.Errorf(errRegionNotAllowed, "nyc1", "ams3, fra1"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateRegion(tc.region, tc.allowed)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateRegion(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+
+	tr, err := newTransport(v1alpha1.ProviderConfigSpec{ProxyURL: &proxy.URL}, nil)
+	if err != nil {
+		t.Fatalf("newTransport(...): %v", err)
+	}
+	res, err := (&http.Client{Transport: tr}).Get("http://api.digitalocean.example/v2/account")
+	if err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	res.Body.Close()
+
+	if diff := cmp.Diff([]string{"http://api.digitalocean.example/v2/account"}, proxied); diff != "" {
+		t.Errorf("newTransport(...): -want proxied requests, +got:\n%s", diff)
+	}
+}
+
+func TestNewTransportCACert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	ref := &xpv1.SecretKeySelector{Key: "ca.crt"}
+
+	cases := map[string]struct {
+		spec    v1alpha1.ProviderConfigSpec
+		caCert  []byte
+		want    error
+		trusted bool
+	}{
+		"SystemCAs": {},
+		"CACert": {
+			spec:    v1alpha1.ProviderConfigSpec{CACertSecretRef: ref},
+			caCert:  caCert,
+			trusted: true,
+		},
+		"InvalidCACert": {
+			spec:   v1alpha1.ProviderConfigSpec{CACertSecretRef: ref},
+			caCert: []byte("not a certificate"),
+			want:   errors.New(errInvalidCACert),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tr, err := newTransport(tc.spec, tc.caCert)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("newTransport(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+			if err == nil {
+				res.Body.Close()
+			}
+			if trusted := err == nil; trusted != tc.trusted {
+				t.Errorf("Get(...): want trusted %t, got error %v", tc.trusted, err)
+			}
+		})
+	}
+}
+
+func TestTransportCache(t *testing.T) {
+	proxy := "http://proxy.example:3128"
+	pc := func(version string, s v1alpha1.ProviderConfigSpec) *v1alpha1.ProviderConfig {
+		return &v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", ResourceVersion: version}, Spec: s}
+	}
+	c := &transportCache{transports: map[string]cachedTransport{}}
+	get := func(p *v1alpha1.ProviderConfig) http.RoundTripper {
+		t.Helper()
+		rt, err := c.get(p, nil)
+		if err != nil {
+			t.Fatalf("get(...): %v", err)
+		}
+		return rt
+	}
+
+	if rt := get(pc("1", v1alpha1.ProviderConfigSpec{})); rt != http.DefaultTransport {
+		t.Errorf("get(...): want http.DefaultTransport without a proxy or CA certificates, got %v", rt)
+	}
+	first := get(pc("1", v1alpha1.ProviderConfigSpec{ProxyURL: &proxy}))
+	if first == http.DefaultTransport {
+		t.Errorf("get(...): want a transport of the ProviderConfig, got http.DefaultTransport")
+	}
+	if rt := get(pc("1", v1alpha1.ProviderConfigSpec{ProxyURL: &proxy})); rt != first {
+		t.Errorf("get(...): want the cached transport of an unchanged ProviderConfig")
+	}
+	if rt := get(pc("2", v1alpha1.ProviderConfigSpec{ProxyURL: &proxy})); rt == first {
+		t.Errorf("get(...): want a new transport once the ProviderConfig changed")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/godo"
+)
+
+// Wildcard matches any single segment of a path registered with Handle.
+const Wildcard = "*"
+
+// A HandlerFunc answers a request to a Server. It is passed the request and
+// the path segments that matched a Wildcard, in order, and returns the status
+// code and the body to respond with. A nil body responds without content.
+type HandlerFunc func(r *http.Request, args []string) (int, interface{})
+
+// ErrorBody is the body the DigitalOcean API responds to failed requests
+// with. godo decodes it into a godo.ErrorResponse.
+type ErrorBody struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// Error returns the status code and body of a failed request.
+func Error(code int, message string) (int, interface{}) {
+	return code, ErrorBody{ID: strings.ToLower(strings.ReplaceAll(http.StatusText(code), " ", "_")), Message: message}
+}
+
+// DecodeBody decodes the JSON body of the supplied request into v.
+func DecodeBody(r *http.Request, v interface{}) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  HandlerFunc
+}
+
+// match returns the path segments that matched a Wildcard, and whether the
+// supplied request matched the route at all.
+func (rt route) match(r *http.Request) ([]string, bool) {
+	if r.Method != rt.method {
+		return nil, false
+	}
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	args := []string{}
+	for i, s := range rt.segments {
+		switch {
+		case s == Wildcard:
+			args = append(args, segments[i])
+		case s != segments[i]:
+			return nil, false
+		}
+	}
+	return args, true
+}
+
+// A Server is a fake DigitalOcean API that answers requests with the handlers
+// registered for them. It lets controllers be exercised end to end through a
+// real godo.Client. Requests without a handler fail with 501 Not Implemented
+// so that tests notice calls they didn't expect.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   []route
+	requests []string
+}
+
+// NewServer starts and returns a new Server. Callers should Close it when
+// they are done.
+func NewServer() *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Handle registers the supplied handler for requests with the supplied method
+// and path, e.g. "/v2/databases/*". Handlers registered later take precedence.
+func (s *Server) Handle(method, path string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rt := route{method: method, segments: strings.Split(strings.Trim(path, "/"), "/"), handler: h}
+	s.routes = append([]route{rt}, s.routes...)
+}
+
+// Client returns a godo.Client that sends its requests to the Server.
+func (s *Server) Client() *godo.Client {
+	c, err := godo.New(s.Server.Client(), godo.SetBaseURL(s.URL))
+	if err != nil {
+		// The base URL of an httptest.Server always parses.
+		panic(err)
+	}
+	return c
+}
+
+// Requests returns the method and path of every request the Server received,
+// e.g. "GET /v2/databases/example", in the order they were received.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	routes := s.routes
+	s.mu.Unlock()
+
+	code, body := Error(http.StatusNotImplemented, fmt.Sprintf("no handler for %s %s", r.Method, r.URL.Path))
+	for _, rt := range routes {
+		if args, ok := rt.match(r); ok {
+			code, body = rt.handler(r, args)
+			break
+		}
+	}
+
+	if body == nil {
+		w.WriteHeader(code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,34 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// this ensures that the mock implements the client interface
+var _ clients.ResourceTagsClient = (*MockResourceTagsClient)(nil)
+
+// MockResourceTagsClient is a type that implements all the methods for ResourceTagsClient interface
+type MockResourceTagsClient struct {
+	MockCreate         func(context.Context, *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error)
+	MockTagResources   func(context.Context, string, *godo.TagResourcesRequest) (*godo.Response, error)
+	MockUntagResources func(context.Context, string, *godo.UntagResourcesRequest) (*godo.Response, error)
+}
+
+// Create mocks Create method
+func (c *MockResourceTagsClient) Create(ctx context.Context, request *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error) {
+	return c.MockCreate(ctx, request)
+}
+
+// TagResources mocks TagResources method
+func (c *MockResourceTagsClient) TagResources(ctx context.Context, tag string, request *godo.TagResourcesRequest) (*godo.Response, error) {
+	return c.MockTagResources(ctx, tag, request)
+}
+
+// UntagResources mocks UntagResources method
+func (c *MockResourceTagsClient) UntagResources(ctx context.Context, tag string, request *godo.UntagResourcesRequest) (*godo.Response, error) {
+	return c.MockUntagResources(ctx, tag, request)
+}
@@ -0,0 +1,70 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+)
+
+// this ensures that the mock implements the client interface
+var _ kubernetes.KubernetesClient = (*MockKubernetesClient)(nil)
+
+// MockKubernetesClient is a type that implements all the methods for KubernetesClient interface
+type MockKubernetesClient struct {
+	MockGet            func(context.Context, string) (*godo.KubernetesCluster, *godo.Response, error)
+	MockList           func(context.Context, *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error)
+	MockGetKubeConfig  func(context.Context, string) (*godo.KubernetesClusterConfig, *godo.Response, error)
+	MockCreate         func(context.Context, *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error)
+	MockDelete         func(context.Context, string) (*godo.Response, error)
+	MockAddRegistry    func(context.Context, *godo.KubernetesClusterRegistryRequest) (*godo.Response, error)
+	MockRemoveRegistry func(context.Context, *godo.KubernetesClusterRegistryRequest) (*godo.Response, error)
+	MockUpdateNodePool func(context.Context, string, string, *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error)
+	MockDeleteNode     func(context.Context, string, string, string, *godo.KubernetesNodeDeleteRequest) (*godo.Response, error)
+}
+
+// Get mocks Get method
+func (c *MockKubernetesClient) Get(ctx context.Context, id string) (*godo.KubernetesCluster, *godo.Response, error) {
+	return c.MockGet(ctx, id)
+}
+
+// List mocks List method
+func (c *MockKubernetesClient) List(ctx context.Context, opt *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error) {
+	return c.MockList(ctx, opt)
+}
+
+// GetKubeConfig mocks GetKubeConfig method
+func (c *MockKubernetesClient) GetKubeConfig(ctx context.Context, id string) (*godo.KubernetesClusterConfig, *godo.Response, error) {
+	return c.MockGetKubeConfig(ctx, id)
+}
+
+// Create mocks Create method
+func (c *MockKubernetesClient) Create(ctx context.Context, request *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error) {
+	return c.MockCreate(ctx, request)
+}
+
+// Delete mocks Delete method
+func (c *MockKubernetesClient) Delete(ctx context.Context, id string) (*godo.Response, error) {
+	return c.MockDelete(ctx, id)
+}
+
+// AddRegistry mocks AddRegistry method
+func (c *MockKubernetesClient) AddRegistry(ctx context.Context, request *godo.KubernetesClusterRegistryRequest) (*godo.Response, error) {
+	return c.MockAddRegistry(ctx, request)
+}
+
+// RemoveRegistry mocks RemoveRegistry method
+func (c *MockKubernetesClient) RemoveRegistry(ctx context.Context, request *godo.KubernetesClusterRegistryRequest) (*godo.Response, error) {
+	return c.MockRemoveRegistry(ctx, request)
+}
+
+// UpdateNodePool mocks UpdateNodePool method
+func (c *MockKubernetesClient) UpdateNodePool(ctx context.Context, clusterID, poolID string, request *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+	return c.MockUpdateNodePool(ctx, clusterID, poolID, request)
+}
+
+// DeleteNode mocks DeleteNode method
+func (c *MockKubernetesClient) DeleteNode(ctx context.Context, clusterID, poolID, nodeID string, request *godo.KubernetesNodeDeleteRequest) (*godo.Response, error) {
+	return c.MockDeleteNode(ctx, clusterID, poolID, nodeID, request)
+}
@@ -14,6 +14,12 @@ limitations under the License.
 package kubernetes
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/digitalocean/godo"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -22,6 +28,77 @@ import (
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+// msgNodePoolRolling is the message of the condition of a cluster whose node
+// pool is replacing its nodes.
+const msgNodePoolRolling = "node pool %q is replacing its nodes"
+
+// KubernetesClient is the external client used for DOKubernetesCluster Custom Resource
+type KubernetesClient interface {
+	Get(context.Context, string) (*godo.KubernetesCluster, *godo.Response, error)
+	List(context.Context, *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error)
+	GetKubeConfig(context.Context, string) (*godo.KubernetesClusterConfig, *godo.Response, error)
+	Create(context.Context, *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	AddRegistry(context.Context, *godo.KubernetesClusterRegistryRequest) (*godo.Response, error)
+	RemoveRegistry(context.Context, *godo.KubernetesClusterRegistryRequest) (*godo.Response, error)
+	UpdateNodePool(context.Context, string, string, *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error)
+	DeleteNode(context.Context, string, string, string, *godo.KubernetesNodeDeleteRequest) (*godo.Response, error)
+}
+
+// AnnotationRecycleNodes lists the IDs of the nodes of a DOKubernetesCluster
+// that should be recycled, i.e. replaced by new nodes, separated by commas.
+// The annotation is removed once the nodes were recycled.
+const AnnotationRecycleNodes = "do.crossplane.io/recycle-nodes"
+
+// systemLabelDomains are the domains of the node pool labels that are set by
+// DigitalOcean or Kubernetes rather than by the user.
+var systemLabelDomains = []string{"digitalocean.com", "kubernetes.io", "k8s.io"}
+
+// ValidateTags returns an error if any tag of the supplied
+// DOKubernetesClusterParameters or of their node pools would be rejected by
+// DigitalOcean.
+func ValidateTags(in v1alpha1.DOKubernetesClusterParameters) error {
+	if err := do.ValidateTags(in.Tags); err != nil {
+		return err
+	}
+	for _, np := range in.NodePools {
+		if err := do.ValidateTags(np.Tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByName returns the Kubernetes cluster with the supplied name, or nil if
+// there is none.
+func FindByName(ctx context.Context, c KubernetesClient, name string) (*godo.KubernetesCluster, error) {
+	opt := &godo.ListOptions{PerPage: do.MaxListPerPage}
+	for {
+		page, resp, err := c.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, k8s := range page {
+			if k8s.Name == name {
+				return k8s, nil
+			}
+		}
+		more, err := do.NextPage(resp, opt)
+		if err != nil || !more {
+			return nil, err
+		}
+	}
+}
+
+// WasCreatedFor returns true if the supplied observed cluster looks like the
+// one that would be created for the supplied parameters by a managed resource
+// that was created at the supplied time. A cluster that existed before the
+// managed resource can't have been created by it.
+func WasCreatedFor(observed godo.KubernetesCluster, p v1alpha1.DOKubernetesClusterParameters, created time.Time) bool {
+	return observed.RegionSlug == p.Region &&
+		!observed.CreatedAt.Before(created)
+}
+
 // GenerateKubernetes generates *godo.KubernetesRequest instance from DOKubernetesClusterParameters.
 func GenerateKubernetes(name string, in v1alpha1.DOKubernetesClusterParameters, create *godo.KubernetesClusterCreateRequest) {
 	create.Name = name
@@ -181,13 +258,19 @@ func getDayFromParam(day string) godo.KubernetesMaintenancePolicyDay {
 	}
 }
 
-// SetCondition sets the condition for a DOKubernetesCluster resource from its state
+// SetCondition sets the condition for a DOKubernetesCluster resource from its state.
+// A running cluster is unavailable while one of its node pools replaces its
+// nodes, e.g. because its taints changed.
 func SetCondition(cr *v1alpha1.DOKubernetesCluster) {
 	switch cr.Status.AtProvider.Status.State {
 	case v1alpha1.KubernetesStateProvisioning:
 		cr.Status.SetConditions(xpv1.Creating())
 	case v1alpha1.KubernetesStateRunning:
-		fallthrough
+		if pool, ok := RollingNodePool(cr.Status.AtProvider); ok {
+			cr.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgNodePoolRolling, pool)))
+			return
+		}
+		cr.Status.SetConditions(xpv1.Available())
 	case v1alpha1.KubernetesStateDegraded: // Still available just in a poor state
 		cr.Status.SetConditions(xpv1.Available())
 	case v1alpha1.KubernetesStateDeleting:
@@ -201,6 +284,20 @@ func SetCondition(cr *v1alpha1.DOKubernetesCluster) {
 	}
 }
 
+// RollingNodePool returns the name of the first observed node pool that has
+// nodes that aren't running, i.e. that are still being provisioned or are
+// being drained and deleted, and whether there is such a node pool.
+func RollingNodePool(o v1alpha1.DOKubernetesClusterObservation) (string, bool) {
+	for _, pool := range o.NodePools {
+		for _, node := range pool.Nodes {
+			if node.Status.State != v1alpha1.KubernetesStateRunning {
+				return pool.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
 // LateInitializeSpec updates any unset (i.e. nil) optional fields of the
 // supplied DOKubernetesClusterParameters that are set (i.e. non-zero) on the supplied
 // Kubernetes Cluster.
@@ -210,4 +307,158 @@ func LateInitializeSpec(p *v1alpha1.DOKubernetesClusterParameters, observed godo
 	p.AutoUpgrade = do.LateInitializeBool(p.AutoUpgrade, observed.AutoUpgrade)
 	p.SurgeUpgrade = do.LateInitializeBool(p.SurgeUpgrade, observed.SurgeUpgrade)
 	p.HighlyAvailable = do.LateInitializeBool(p.HighlyAvailable, observed.HA)
+	p.RegistryIntegration = do.LateInitializeBool(p.RegistryIntegration, observed.RegistryEnabled)
+}
+
+// IsUpToDate returns true if the supplied observation matches the
+// DOKubernetesClusterParameters fields that can be updated in place.
+func IsUpToDate(p v1alpha1.DOKubernetesClusterParameters, o v1alpha1.DOKubernetesClusterObservation) bool {
+	if p.RegistryIntegration != nil && *p.RegistryIntegration != o.RegistryEnabled {
+		return false
+	}
+	return len(GenerateNodePoolUpdates(p, o)) == 0
+}
+
+// NodePoolUpdate is an update of the node pool with the given ID.
+type NodePoolUpdate struct {
+	ID      string
+	Request *godo.KubernetesNodePoolUpdateRequest
+}
+
+// GenerateNodePoolUpdates returns the updates that reconcile the labels and
+// taints of the observed node pools with the desired ones. Node pools are
+// matched by name, and only those whose labels or taints drifted are updated.
+// Labels or taints that aren't specified for a node pool aren't managed.
+func GenerateNodePoolUpdates(p v1alpha1.DOKubernetesClusterParameters, o v1alpha1.DOKubernetesClusterObservation) []NodePoolUpdate {
+	observed := make(map[string]v1alpha1.KubernetesNodePoolObservation, len(o.NodePools))
+	for _, np := range o.NodePools {
+		observed[np.Name] = np
+	}
+
+	var updates []NodePoolUpdate
+	for _, np := range p.NodePools {
+		onp, ok := observed[np.Name]
+		if !ok || isNodePoolUpToDate(np, onp) {
+			continue
+		}
+		updates = append(updates, NodePoolUpdate{ID: onp.ID, Request: generateNodePoolUpdate(np, onp)})
+	}
+	return updates
+}
+
+// IsSystemLabel returns true if the supplied node pool label is set by
+// DigitalOcean or Kubernetes rather than by the user, e.g.
+// doks.digitalocean.com/node-pool.
+func IsSystemLabel(key string) bool {
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return false
+	}
+	prefix := key[:i]
+	for _, d := range systemLabelDomains {
+		if prefix == d || strings.HasSuffix(prefix, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNodePoolUpToDate(p v1alpha1.KubernetesNodePool, o v1alpha1.KubernetesNodePoolObservation) bool {
+	if p.Labels != nil && !reflect.DeepEqual(userLabels(p.Labels), userLabels(o.Labels)) {
+		return false
+	}
+	return p.Taints == nil || equalTaints(p.Taints, o.Taints)
+}
+
+func generateNodePoolUpdate(p v1alpha1.KubernetesNodePool, o v1alpha1.KubernetesNodePoolObservation) *godo.KubernetesNodePoolUpdateRequest {
+	// The count is kept as observed so that the update doesn't interfere with
+	// the autoscaler.
+	count := o.Count
+	req := &godo.KubernetesNodePoolUpdateRequest{Name: o.Name, Count: &count, Labels: o.Labels}
+
+	if p.Labels != nil {
+		// System labels are kept so that DigitalOcean doesn't have to set them
+		// again.
+		req.Labels = userLabels(p.Labels)
+		for k, v := range o.Labels {
+			if IsSystemLabel(k) {
+				req.Labels[k] = v
+			}
+		}
+	}
+
+	if p.Taints != nil {
+		taints := make([]godo.Taint, len(p.Taints))
+		for i, t := range p.Taints {
+			taints[i] = godo.Taint{Key: t.Key, Value: t.Value, Effect: t.Effect}
+		}
+		req.Taints = &taints
+	}
+	return req
+}
+
+func userLabels(labels map[string]string) map[string]string {
+	user := map[string]string{}
+	for k, v := range labels {
+		if !IsSystemLabel(k) {
+			user[k] = v
+		}
+	}
+	return user
+}
+
+// equalTaints returns true if the supplied taints are the same, regardless of
+// their order.
+func equalTaints(a, b []v1alpha1.KubernetesNodePoolTaint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[v1alpha1.KubernetesNodePoolTaint]int, len(a))
+	for _, t := range a {
+		counts[t]++
+	}
+	for _, t := range b {
+		if counts[t] == 0 {
+			return false
+		}
+		counts[t]--
+	}
+	return true
+}
+
+// NodesToRecycle returns the IDs of the nodes that the supplied
+// DOKubernetesCluster requests to be recycled.
+func NodesToRecycle(cr *v1alpha1.DOKubernetesCluster) []string {
+	var ids []string
+	for _, id := range strings.Split(cr.GetAnnotations()[AnnotationRecycleNodes], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// NodePoolOf returns the ID of the node pool that the node with the supplied
+// ID belongs to, or false if the node isn't part of the observed cluster.
+func NodePoolOf(o v1alpha1.DOKubernetesClusterObservation, nodeID string) (string, bool) {
+	for _, np := range o.NodePools {
+		for _, n := range np.Nodes {
+			if n.ID == nodeID {
+				return np.ID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ObservedNodes returns the IDs of the supplied nodes that are still part of
+// the observed cluster.
+func ObservedNodes(o v1alpha1.DOKubernetesClusterObservation, ids []string) []string {
+	var observed []string
+	for _, id := range ids {
+		if _, ok := NodePoolOf(o, id); ok {
+			observed = append(observed, id)
+		}
+	}
+	return observed
 }
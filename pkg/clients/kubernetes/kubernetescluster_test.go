@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+)
+
+func TestGenerateNodePoolUpdates(t *testing.T) {
+	gpu := v1alpha1.KubernetesNodePoolTaint{Key: "gpu", Value: "true", Effect: "NoSchedule"}
+	spot := v1alpha1.KubernetesNodePoolTaint{Key: "spot", Value: "true", Effect: "NoExecute"}
+	observed := func(labels map[string]string, taints ...v1alpha1.KubernetesNodePoolTaint) v1alpha1.DOKubernetesClusterObservation {
+		return v1alpha1.DOKubernetesClusterObservation{NodePools: []v1alpha1.KubernetesNodePoolObservation{
+			{ID: "pool-id", Name: "pool", Count: 3, Labels: labels, Taints: taints},
+		}}
+	}
+	desired := func(labels map[string]string, taints ...v1alpha1.KubernetesNodePoolTaint) v1alpha1.DOKubernetesClusterParameters {
+		return v1alpha1.DOKubernetesClusterParameters{NodePools: []v1alpha1.KubernetesNodePool{
+			{Name: "pool", Count: 1, Labels: labels, Taints: taints},
+		}}
+	}
+
+	tests := map[string]struct {
+		p    v1alpha1.DOKubernetesClusterParameters
+		o    v1alpha1.DOKubernetesClusterObservation
+		want []NodePoolUpdate
+	}{
+		"UpToDateWithSystemLabels": {
+			p: desired(map[string]string{"team": "a"}, gpu),
+			o: observed(map[string]string{"team": "a", "doks.digitalocean.com/node-pool": "pool"}, gpu),
+		},
+		"UnmanagedLabelsAndTaints": {
+			p: desired(nil),
+			o: observed(map[string]string{"team": "a"}, gpu),
+		},
+		"TaintsInAnotherOrder": {
+			p: desired(nil, gpu, spot),
+			o: observed(nil, spot, gpu),
+		},
+		"UnknownNodePool": {
+			p: v1alpha1.DOKubernetesClusterParameters{NodePools: []v1alpha1.KubernetesNodePool{{Name: "new", Labels: map[string]string{"team": "a"}}}},
+			o: observed(nil),
+		},
+		"UserLabelsChangedWithSystemLabels": {
+			p: desired(map[string]string{"team": "b", "tier": "web"}, gpu),
+			o: observed(map[string]string{"team": "a", "doks.digitalocean.com/node-pool": "pool"}, gpu),
+			want: []NodePoolUpdate{{ID: "pool-id", Request: &godo.KubernetesNodePoolUpdateRequest{
+				Name:   "pool",
+				Count:  godo.PtrTo(3),
+				Labels: map[string]string{"team": "b", "tier": "web", "doks.digitalocean.com/node-pool": "pool"},
+				Taints: &[]godo.Taint{{Key: "gpu", Value: "true", Effect: "NoSchedule"}},
+			}}},
+		},
+		"TaintsChanged": {
+			p: desired(nil, spot),
+			o: observed(map[string]string{"team": "a"}, gpu),
+			want: []NodePoolUpdate{{ID: "pool-id", Request: &godo.KubernetesNodePoolUpdateRequest{
+				Name:   "pool",
+				Count:  godo.PtrTo(3),
+				Labels: map[string]string{"team": "a"},
+				Taints: &[]godo.Taint{{Key: "spot", Value: "true", Effect: "NoExecute"}},
+			}}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateNodePoolUpdates(tc.p, tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateNodePoolUpdates(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetCondition(t *testing.T) {
+	node := func(state v1alpha1.KubernetesState) v1alpha1.KubernetesNode {
+		return v1alpha1.KubernetesNode{Status: v1alpha1.KubernetesStatus{State: state}}
+	}
+	cluster := func(state v1alpha1.KubernetesState, nodes ...v1alpha1.KubernetesNode) *v1alpha1.DOKubernetesCluster {
+		cr := &v1alpha1.DOKubernetesCluster{}
+		cr.Status.AtProvider.Status.State = state
+		cr.Status.AtProvider.NodePools = []v1alpha1.KubernetesNodePoolObservation{{Name: "pool", Nodes: nodes}}
+		return cr
+	}
+
+	cases := map[string]struct {
+		cr   *v1alpha1.DOKubernetesCluster
+		want xpv1.Condition
+	}{
+		"Running": {
+			cr:   cluster(v1alpha1.KubernetesStateRunning, node(v1alpha1.KubernetesStateRunning)),
+			want: xpv1.Available(),
+		},
+		"ReplacingNodes": {
+			cr:   cluster(v1alpha1.KubernetesStateRunning, node(v1alpha1.KubernetesStateRunning), node(v1alpha1.KubernetesStateProvisioning)),
+			want: xpv1.Unavailable().WithMessage(`node pool "pool" is replacing its nodes`),
+		},
+		"Provisioning": {
+			cr:   cluster(v1alpha1.KubernetesStateProvisioning, node(v1alpha1.KubernetesStateProvisioning)),
+			want: xpv1.Creating(),
+		},
+		"Upgrading": {
+			cr:   cluster(v1alpha1.KubernetesStateUpgrading),
+			want: xpv1.Unavailable(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetCondition(tc.cr)
+			if diff := cmp.Diff(tc.want, tc.cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("SetCondition(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
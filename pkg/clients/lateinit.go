@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// TypeLateInitialized resources have values that were late-initialized from
+// DigitalOcean but not written to their spec.
+const TypeLateInitialized xpv1.ConditionType = "LateInitialized"
+
+// Reasons a resource is or is not late-initialized.
+const (
+	ReasonSpecNotUpdated xpv1.ConditionReason = "SpecNotUpdated"
+	ReasonSpecComplete   xpv1.ConditionReason = "SpecComplete"
+)
+
+// LateInitialized returns a condition that lists the supplied spec fields,
+// whose values were late-initialized but not written to the spec. The
+// condition is false if there are no such fields.
+func LateInitialized(fields []string) xpv1.Condition {
+	c := xpv1.Condition{
+		Type:               TypeLateInitialized,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSpecComplete,
+	}
+	if len(fields) > 0 {
+		c.Status = corev1.ConditionTrue
+		c.Reason = ReasonSpecNotUpdated
+		c.Message = "late-initialized but not written to spec.forProvider: " + strings.Join(fields, ", ")
+	}
+	return c
+}
+
+// LateInitializedFields returns the JSON names of the fields that differ
+// between the supplied structs, or pointers to structs, of the same type.
+func LateInitializedFields(before, after interface{}) []string {
+	b, a := reflect.Indirect(reflect.ValueOf(before)), reflect.Indirect(reflect.ValueOf(after))
+	var fields []string
+	for i := 0; i < a.NumField(); i++ {
+		if reflect.DeepEqual(b.Field(i).Interface(), a.Field(i).Interface()) {
+			continue
+		}
+		f := a.Type().Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// PersistLateInit writes the late-initialized parameters to the spec of the
+// supplied managed resource if they differ from its current parameters. Both
+// must be pointers to structs of the same type, and the late-initialized ones
+// must be a copy. If writing is disabled the spec is left as is, so that later
+// updates of the managed resource don't write the late-initialized values
+// either, and the late-initialized fields are reported by a LateInitialized
+// condition instead.
+func PersistLateInit(ctx context.Context, kube client.Client, mg resource.Managed, current, lateInit interface{}, write bool) error {
+	fields := LateInitializedFields(current, lateInit)
+	if !write {
+		mg.SetConditions(LateInitialized(fields))
+		return nil
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	reflect.ValueOf(current).Elem().Set(reflect.ValueOf(lateInit).Elem())
+	return kube.Update(ctx, mg)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type lateInitParams struct {
+	Region string   `json:"region"`
+	Size   *string  `json:"size,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+func TestPersistLateInit(t *testing.T) {
+	size := "s-1vcpu-1gb"
+	before := lateInitParams{Region: "nyc1"}
+	after := lateInitParams{Region: "nyc1", Size: &size}
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err       error
+		updated   bool
+		spec      lateInitParams
+		condition *xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		after  lateInitParams
+		write  bool
+		update error
+		want   want
+	}{
+		"WritesChangedSpec": {
+			after: after,
+			write: true,
+			want:  want{updated: true, spec: after},
+		},
+		"SkipsUnchangedSpec": {
+			after: before,
+			write: true,
+			want:  want{spec: before},
+		},
+		"UpdateFailed": {
+			after:  after,
+			write:  true,
+			update: errBoom,
+			want:   want{err: errBoom, updated: true, spec: after},
+		},
+		"ReportsChangedFields": {
+			after: after,
+			want: want{
+				spec: before,
+				condition: &xpv1.Condition{
+					Type:    TypeLateInitialized,
+					Status:  "True",
+					Reason:  ReasonSpecNotUpdated,
+					Message: "late-initialized but not written to spec.forProvider: size",
+				},
+			},
+		},
+		"ReportsCompleteSpec": {
+			after: before,
+			want: want{
+				spec: before,
+				condition: &xpv1.Condition{
+					Type:   TypeLateInitialized,
+					Status: "False",
+					Reason: ReasonSpecComplete,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			kube := &test.MockClient{MockUpdate: func(context.Context, client.Object, ...client.UpdateOption) error {
+				updated = true
+				return tc.update
+			}}
+			mg := &fake.Managed{}
+			current, lateInit := before, tc.after
+			err := PersistLateInit(context.Background(), kube, mg, &current, &lateInit, tc.write)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("PersistLateInit(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("PersistLateInit(...): -want updated, +got updated:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.spec, current); diff != "" {
+				t.Errorf("PersistLateInit(...): -want spec, +got spec:\n%s", diff)
+			}
+			if tc.want.condition == nil {
+				return
+			}
+			if diff := cmp.Diff(*tc.want.condition, mg.GetCondition(TypeLateInitialized), test.EquateConditions()); diff != "" {
+				t.Errorf("PersistLateInit(...): -want condition, +got condition:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/loadbalancer"
+)
+
+// this ensures that the mock implements the client interface
+var _ loadbalancer.LBClient = (*MockLBClient)(nil)
+
+// MockLBClient is a type that implements all the methods for LBClient interface
+type MockLBClient struct {
+	MockGet    func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error)
+	MockCreate func(context.Context, *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error)
+	MockUpdate func(context.Context, string, *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error)
+	MockDelete func(context.Context, string) (*godo.Response, error)
+}
+
+// Get mocks Get method
+func (c *MockLBClient) Get(ctx context.Context, id string) (*godo.LoadBalancer, *godo.Response, error) {
+	return c.MockGet(ctx, id)
+}
+
+// Create mocks Create method
+func (c *MockLBClient) Create(ctx context.Context, request *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+	return c.MockCreate(ctx, request)
+}
+
+// Update mocks Update method
+func (c *MockLBClient) Update(ctx context.Context, id string, request *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+	return c.MockUpdate(ctx, id, request)
+}
+
+// Delete mocks Delete method
+func (c *MockLBClient) Delete(ctx context.Context, id string) (*godo.Response, error) {
+	return c.MockDelete(ctx, id)
+}
@@ -17,20 +17,114 @@ limitations under the License.
 package loadbalancer
 
 import (
+	"context"
+	"strings"
+
 	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+// Supported load balancing algorithms.
+const (
+	AlgorithmRoundRobin       = "round_robin"
+	AlgorithmLeastConnections = "least_connections"
+
+	errUnsupportedAlgorithm = "unsupported load balancer algorithm %q, must be one of: %s"
+	errSizeAndSizeUnit      = "only one of size and sizeUnit may be set"
+	errUnsupportedSize      = "unsupported load balancer size %q, must be one of: %s"
+	errSizeUnitOutOfRange   = "load balancer sizeUnit %d is out of range, must be between %d and %d"
+)
+
+// Supported load balancer sizes.
+const (
+	SizeSmall  = "lb-small"
+	SizeMedium = "lb-medium"
+	SizeLarge  = "lb-large"
+
+	// MinSizeUnit and MaxSizeUnit bound the number of nodes a LB can be
+	// scaled to.
+	MinSizeUnit = 1
+	MaxSizeUnit = 100
+)
+
+// SupportedSizes returns the load balancer size slugs DigitalOcean accepts.
+func SupportedSizes() []string {
+	return []string{SizeSmall, SizeMedium, SizeLarge}
+}
+
+// SupportedAlgorithms returns the load balancing algorithms DigitalOcean
+// accepts.
+func SupportedAlgorithms() []string {
+	return []string{AlgorithmRoundRobin, AlgorithmLeastConnections}
+}
+
+// ValidateAlgorithm returns an error if the supplied load balancing algorithm
+// is not supported by DigitalOcean.
+func ValidateAlgorithm(a string) error {
+	for _, s := range SupportedAlgorithms() {
+		if a == s {
+			return nil
+		}
+	}
+	return errors.Errorf(errUnsupportedAlgorithm, a, strings.Join(SupportedAlgorithms(), ", "))
+}
+
+// ValidateSize returns an error if both the size slug and the size unit of the
+// supplied LBParameters are set, as they are mutually exclusive, or if the one
+// that is set isn't accepted by DigitalOcean.
+func ValidateSize(p v1alpha1.LBParameters) error {
+	if p.Size != nil && p.SizeUnit != nil {
+		return errors.New(errSizeAndSizeUnit)
+	}
+	if p.SizeUnit != nil && (*p.SizeUnit < MinSizeUnit || *p.SizeUnit > MaxSizeUnit) {
+		return errors.Errorf(errSizeUnitOutOfRange, *p.SizeUnit, MinSizeUnit, MaxSizeUnit)
+	}
+	if p.Size == nil {
+		return nil
+	}
+	for _, s := range SupportedSizes() {
+		if *p.Size == s {
+			return nil
+		}
+	}
+	return errors.Errorf(errUnsupportedSize, *p.Size, strings.Join(SupportedSizes(), ", "))
+}
+
+// Validate returns an error if the supplied LBParameters can't be used to
+// create or update a LB.
+func Validate(p v1alpha1.LBParameters) error {
+	if err := ValidateAlgorithm(p.Algorithm); err != nil {
+		return err
+	}
+	if err := do.ValidateTags(p.Tags); err != nil {
+		return err
+	}
+	return ValidateSize(p)
+}
+
+// LBClient is the external client used for LB Custom Resource
+type LBClient interface {
+	Get(context.Context, string) (*godo.LoadBalancer, *godo.Response, error)
+	Create(context.Context, *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error)
+	Update(context.Context, string, *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+}
+
 // GenerateLoadBalancer generates *godo.LoadBalancerRequest instance from LBParameters.
 func GenerateLoadBalancer(name string, in v1alpha1.LBParameters, create *godo.LoadBalancerRequest) {
 	create.Name = name
 	create.Region = in.Region
 	create.Algorithm = in.Algorithm
+	create.SizeSlug = do.StringValue(in.Size)
+	if in.SizeUnit != nil {
+		create.SizeUnit = uint32(*in.SizeUnit)
+	}
 	create.ForwardingRules = append(create.ForwardingRules, generateForwardRule(in.Port))
 	create.HealthCheck = generateHealthCheck(in.HealthCheck, in.Port)
-	create.Tags = in.Tags
+	create.Tags = do.FilterIgnoredTags(in.Tags, in.IgnoreTagsMatching)
 	create.VPCUUID = do.StringValue(in.VPCUUID)
 }
 
@@ -71,6 +165,51 @@ func generateHealthCheck(in v1alpha1.DOLoadBalancerHealthCheck, inPort int) *god
 // supplied LBParameters that are set (i.e. non-zero) on the supplied
 // LB.
 func LateInitializeSpec(p *v1alpha1.LBParameters, observed godo.LoadBalancer) {
-	p.Tags = do.LateInitializeStringSlice(p.Tags, observed.Tags)
 	p.VPCUUID = do.LateInitializeString(p.VPCUUID, observed.VPCUUID)
+
+	if p.Size == nil && p.SizeUnit == nil && observed.SizeUnit != 0 {
+		u := int(observed.SizeUnit)
+		p.SizeUnit = &u
+	}
+}
+
+// IsUpToDate returns true if the supplied LB matches the LBParameters fields
+// that can be updated in place.
+func IsUpToDate(p v1alpha1.LBParameters, observed godo.LoadBalancer) bool {
+	if p.SizeUnit != nil && uint32(*p.SizeUnit) != observed.SizeUnit {
+		return false
+	}
+	if p.Size != nil && *p.Size != observed.SizeSlug {
+		return false
+	}
+	return p.Algorithm == observed.Algorithm
+}
+
+// GenerateUpdate generates the *godo.LoadBalancerRequest used to update the
+// supplied LB so that it matches LBParameters. Updates replace the whole load
+// balancer, so the request starts from the observed state in order to keep
+// settings, such as attached droplets, that are not managed here.
+func GenerateUpdate(p v1alpha1.LBParameters, observed godo.LoadBalancer) *godo.LoadBalancerRequest {
+	update := observed.AsRequest()
+	update.Algorithm = p.Algorithm
+
+	// DigitalOcean reports the droplets of a LB that targets droplets by tag,
+	// but rejects requests that set both.
+	if update.Tag != "" {
+		update.DropletIDs = nil
+	}
+
+	// The size slug and size unit are mutually exclusive, so only one of
+	// them may be sent.
+	switch {
+	case p.SizeUnit != nil:
+		update.SizeUnit = uint32(*p.SizeUnit)
+		update.SizeSlug = ""
+	case p.Size != nil:
+		update.SizeSlug = *p.Size
+		update.SizeUnit = 0
+	case update.SizeUnit != 0:
+		update.SizeSlug = ""
+	}
+	return update
 }
@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
+)
+
+func TestValidateAlgorithm(t *testing.T) {
+	accepted := strings.Join(SupportedAlgorithms(), ", ")
+	cases := map[string]struct {
+		algorithm string
+		want      error
+	}{
+		"RoundRobin": {
+			algorithm: "round_robin",
+		},
+		"LeastConnections": {
+			algorithm: "least_connections",
+		},
+		"Unsupported": {
+			algorithm: "weighted",
+			want:      errors.Errorf(errUnsupportedAlgorithm, "weighted", accepted),
+		},
+		"Empty": {
+			algorithm: "",
+			want:      errors.Errorf(errUnsupportedAlgorithm, "", accepted),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateAlgorithm(tc.algorithm)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateAlgorithm(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUpdate(t *testing.T) {
+	observed := godo.LoadBalancer{
+		ID:         "lb",
+		Name:       "lb",
+		Algorithm:  AlgorithmRoundRobin,
+		DropletIDs: []int{1, 2},
+		Region:     &godo.Region{Slug: "nyc1"},
+	}
+	p := v1alpha1.LBParameters{Algorithm: AlgorithmLeastConnections}
+
+	if IsUpToDate(p, observed) {
+		t.Errorf("IsUpToDate(...): want false for a changed algorithm")
+	}
+
+	want := &godo.LoadBalancerRequest{
+		Name:       "lb",
+		Algorithm:  AlgorithmLeastConnections,
+		DropletIDs: []int{1, 2},
+		Region:     "nyc1",
+	}
+	if diff := cmp.Diff(want, GenerateUpdate(p, observed)); diff != "" {
+		t.Errorf("GenerateUpdate(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGenerateUpdateTagTargeted(t *testing.T) {
+	observed := godo.LoadBalancer{
+		ID:         "lb",
+		Name:       "lb",
+		Algorithm:  AlgorithmRoundRobin,
+		Tag:        "web",
+		DropletIDs: []int{1, 2},
+	}
+	p := v1alpha1.LBParameters{Algorithm: AlgorithmLeastConnections}
+
+	want := &godo.LoadBalancerRequest{
+		Name:      "lb",
+		Algorithm: AlgorithmLeastConnections,
+		Tag:       "web",
+	}
+	if diff := cmp.Diff(want, GenerateUpdate(p, observed)); diff != "" {
+		t.Errorf("GenerateUpdate(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestValidateSize(t *testing.T) {
+	cases := map[string]struct {
+		p    v1alpha1.LBParameters
+		want error
+	}{
+		"Neither": {},
+		"SizeOnly": {
+			p: v1alpha1.LBParameters{Size: godo.String("lb-small")},
+		},
+		"SizeUnitOnly": {
+			p: v1alpha1.LBParameters{SizeUnit: godo.Int(2)},
+		},
+		"Both": {
+			p:    v1alpha1.LBParameters{Size: godo.String("lb-small"), SizeUnit: godo.Int(2)},
+			want: errors.New(errSizeAndSizeUnit),
+		},
+		"UnsupportedSize": {
+			p:    v1alpha1.LBParameters{Size: godo.String("lb-huge")},
+			want: errors.Errorf(errUnsupportedSize, "lb-huge", "lb-small, lb-medium, lb-large"),
+		},
+		"SizeUnitTooSmall": {
+			p:    v1alpha1.LBParameters{SizeUnit: godo.Int(0)},
+			want: errors.Errorf(errSizeUnitOutOfRange, 0, MinSizeUnit, MaxSizeUnit),
+		},
+		"SizeUnitTooLarge": {
+			p:    v1alpha1.LBParameters{SizeUnit: godo.Int(101)},
+			want: errors.Errorf(errSizeUnitOutOfRange, 101, MinSizeUnit, MaxSizeUnit),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateSize(tc.p)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateSize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSizeUnit(t *testing.T) {
+	observed := godo.LoadBalancer{
+		ID:        "lb",
+		Name:      "lb",
+		Algorithm: AlgorithmRoundRobin,
+		SizeSlug:  "lb-small",
+		SizeUnit:  1,
+	}
+	p := v1alpha1.LBParameters{Algorithm: AlgorithmRoundRobin, SizeUnit: godo.Int(3)}
+
+	if IsUpToDate(p, observed) {
+		t.Errorf("IsUpToDate(...): want false for a changed size unit")
+	}
+
+	want := &godo.LoadBalancerRequest{
+		Name:      "lb",
+		Algorithm: AlgorithmRoundRobin,
+		SizeUnit:  3,
+	}
+	if diff := cmp.Diff(want, GenerateUpdate(p, observed)); diff != "" {
+		t.Errorf("GenerateUpdate(...): -want, +got:\n%s", diff)
+	}
+
+	create := &godo.LoadBalancerRequest{}
+	GenerateLoadBalancer("lb", p, create)
+	if create.SizeUnit != 3 || create.SizeSlug != "" {
+		t.Errorf("GenerateLoadBalancer(...): want size unit 3 and no size slug, got %d and %q", create.SizeUnit, create.SizeSlug)
+	}
+}
+
+func TestSize(t *testing.T) {
+	observed := godo.LoadBalancer{
+		ID:        "lb",
+		Name:      "lb",
+		Algorithm: AlgorithmRoundRobin,
+		SizeSlug:  SizeSmall,
+	}
+	p := v1alpha1.LBParameters{Algorithm: AlgorithmRoundRobin, Size: godo.String(SizeLarge)}
+
+	if IsUpToDate(p, observed) {
+		t.Errorf("IsUpToDate(...): want false for a changed size")
+	}
+
+	want := &godo.LoadBalancerRequest{
+		Name:      "lb",
+		Algorithm: AlgorithmRoundRobin,
+		SizeSlug:  SizeLarge,
+	}
+	if diff := cmp.Diff(want, GenerateUpdate(p, observed)); diff != "" {
+		t.Errorf("GenerateUpdate(...): -want, +got:\n%s", diff)
+	}
+
+	observed.SizeSlug = SizeLarge
+	if !IsUpToDate(p, observed) {
+		t.Errorf("IsUpToDate(...): want true for an unchanged size")
+	}
+}
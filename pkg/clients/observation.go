@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// A StatusConditions maps the statuses DigitalOcean reports for a resource to
+// the Crossplane condition each of them results in.
+type StatusConditions map[string]func() xpv1.Condition
+
+// SetStatusCondition sets the condition the supplied status maps to on the
+// supplied managed resource. Statuses that aren't mapped leave the conditions
+// of the managed resource unchanged.
+func SetStatusCondition(mg resource.Managed, status string, m StatusConditions) {
+	if c, ok := m[status]; ok {
+		mg.SetConditions(c())
+	}
+}
+
+// FormatTime returns the supplied time as a string, or an empty string if it
+// is the zero time, i.e. it was not reported by DigitalOcean.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.String()
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestSetStatusCondition(t *testing.T) {
+	m := StatusConditions{
+		"new":    xpv1.Creating,
+		"active": xpv1.Available,
+	}
+
+	cases := map[string]struct {
+		status string
+		want   []xpv1.Condition
+	}{
+		"Creating": {
+			status: "new",
+			want:   []xpv1.Condition{xpv1.Creating()},
+		},
+		"Available": {
+			status: "active",
+			want:   []xpv1.Condition{xpv1.Available()},
+		},
+		"Unmapped": {
+			status: "off",
+			want:   []xpv1.Condition{xpv1.Unavailable()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(xpv1.Unavailable())
+			SetStatusCondition(mg, tc.status, m)
+			if diff := cmp.Diff(tc.want, mg.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("SetStatusCondition(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	ts := time.Date(2021, 5, 4, 12, 30, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		t    time.Time
+		want string
+	}{
+		"Zero": {},
+		"Set": {
+			t:    ts,
+			want: "2021-05-04 12:30:00 +0000 UTC",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := FormatTime(tc.t); got != tc.want {
+				t.Errorf("FormatTime(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
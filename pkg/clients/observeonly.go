@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	errObserveOnlyCreate = "the provider is in observe-only mode and does not create external resources"
+	errObserveOnlyUpdate = "the provider is in observe-only mode and does not update external resources"
+	errObserveOnlyDelete = "the provider is in observe-only mode and does not delete external resources"
+)
+
+// An ObserveOnlyConnecter connects with the ExternalConnecter it wraps, but
+// returns clients that only observe external resources. Their Create, Update
+// and Delete return an error without calling DigitalOcean, so the managed
+// reconciler reports the pending change in the Synced condition and retries
+// it once the provider is no longer observe-only.
+type ObserveOnlyConnecter struct {
+	managed.ExternalConnecter
+}
+
+// NewObserveOnlyConnecter returns an ExternalConnecter that wraps the supplied
+// one if observeOnly is true, and the supplied one otherwise.
+func NewObserveOnlyConnecter(c managed.ExternalConnecter, observeOnly bool) managed.ExternalConnecter {
+	if !observeOnly {
+		return c
+	}
+	return &ObserveOnlyConnecter{ExternalConnecter: c}
+}
+
+// Connect returns an observe-only client for the supplied managed resource.
+func (c *ObserveOnlyConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &observeOnlyExternal{ExternalClient: ec}, nil
+}
+
+// An observeOnlyExternal observes external resources with the ExternalClient
+// it wraps, and refuses to change them.
+type observeOnlyExternal struct {
+	managed.ExternalClient
+}
+
+func (e *observeOnlyExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.New(errObserveOnlyCreate)
+}
+
+func (e *observeOnlyExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, errors.New(errObserveOnlyUpdate)
+}
+
+func (e *observeOnlyExternal) Delete(_ context.Context, _ resource.Managed) error {
+	return errors.New(errObserveOnlyDelete)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestObserveOnlyConnecter(t *testing.T) {
+	drifted := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}
+
+	type want struct {
+		observation managed.ExternalObservation
+		createErr   error
+		updateErr   error
+		deleteErr   error
+		mutations   int
+	}
+	cases := map[string]struct {
+		observeOnly bool
+		want        want
+	}{
+		"BlocksMutations": {
+			observeOnly: true,
+			want: want{
+				observation: drifted,
+				createErr:   errors.New(errObserveOnlyCreate),
+				updateErr:   errors.New(errObserveOnlyUpdate),
+				deleteErr:   errors.New(errObserveOnlyDelete),
+			},
+		},
+		"Disabled": {
+			want: want{
+				observation: drifted,
+				mutations:   3,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mutations := 0
+			ec := &managed.ExternalClientFns{
+				ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+					return drifted, nil
+				},
+				CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+					mutations++
+					return managed.ExternalCreation{}, nil
+				},
+				UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+					mutations++
+					return managed.ExternalUpdate{}, nil
+				},
+				DeleteFn: func(context.Context, resource.Managed) error {
+					mutations++
+					return nil
+				},
+			}
+			c := NewObserveOnlyConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return ec, nil
+			}), tc.observeOnly)
+
+			ctx := context.Background()
+			mg := &fake.Managed{}
+			e, err := c.Connect(ctx, mg)
+			if err != nil {
+				t.Fatalf("Connect(...): %v", err)
+			}
+			o, err := e.Observe(ctx, mg)
+			if err != nil {
+				t.Fatalf("Observe(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want.observation, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			_, err = e.Create(ctx, mg)
+			if diff := cmp.Diff(tc.want.createErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			_, err = e.Update(ctx, mg)
+			if diff := cmp.Diff(tc.want.updateErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			err = e.Delete(ctx, mg)
+			if diff := cmp.Diff(tc.want.deleteErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mutations, mutations); diff != "" {
+				t.Errorf("mutations: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
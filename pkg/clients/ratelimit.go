@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The headers the DigitalOcean API reports the rate limit of a token with.
+// godo parses the same headers into godo.Rate.
+const (
+	headerRateLimit     = "RateLimit-Limit"
+	headerRateRemaining = "RateLimit-Remaining"
+	headerRateReset     = "RateLimit-Reset"
+)
+
+// labelProviderConfig is the metric label that identifies the ProviderConfig,
+// and thus the token, a rate limit applies to.
+const labelProviderConfig = "providerconfig"
+
+var (
+	rateLimitLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "digitalocean_api_rate_limit",
+		Help: "The number of requests per hour the DigitalOcean API allows, as of the last response.",
+	}, []string{labelProviderConfig})
+	rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "digitalocean_api_rate_limit_remaining",
+		Help: "The number of requests the DigitalOcean API allows until the rate limit resets, as of the last response.",
+	}, []string{labelProviderConfig})
+	rateLimitReset = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "digitalocean_api_rate_limit_reset_timestamp_seconds",
+		Help: "The Unix time at which the rate limit of the DigitalOcean API resets, as of the last response.",
+	}, []string{labelProviderConfig})
+)
+
+func init() {
+	metrics.Registry.MustRegister(rateLimitLimit, rateLimitRemaining, rateLimitReset)
+}
+
+// ParseRate returns the rate limit reported by the headers of a response of
+// the DigitalOcean API, and whether they reported one at all.
+func ParseRate(h http.Header) (godo.Rate, bool) {
+	limit, err := strconv.Atoi(h.Get(headerRateLimit))
+	if err != nil {
+		return godo.Rate{}, false
+	}
+	remaining, err := strconv.Atoi(h.Get(headerRateRemaining))
+	if err != nil {
+		return godo.Rate{}, false
+	}
+	reset, err := strconv.ParseInt(h.Get(headerRateReset), 10, 64)
+	if err != nil {
+		return godo.Rate{}, false
+	}
+	return godo.Rate{Limit: limit, Remaining: remaining, Reset: godo.Timestamp{Time: time.Unix(reset, 0)}}, true
+}
+
+// A rateLimitTransport records the rate limit reported by every response of
+// the DigitalOcean API as metrics, so that operators can tell how close the
+// token of a ProviderConfig is to being throttled.
+type rateLimitTransport struct {
+	base           http.RoundTripper
+	providerConfig string
+}
+
+func newRateLimitTransport(base http.RoundTripper, providerConfig string) *rateLimitTransport {
+	return &rateLimitTransport{base: base, providerConfig: providerConfig}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if r, ok := ParseRate(resp.Header); ok {
+		rateLimitLimit.WithLabelValues(t.providerConfig).Set(float64(r.Limit))
+		rateLimitRemaining.WithLabelValues(t.providerConfig).Set(float64(r.Remaining))
+		rateLimitReset.WithLabelValues(t.providerConfig).Set(float64(r.Reset.Unix()))
+	}
+	return resp, nil
+}
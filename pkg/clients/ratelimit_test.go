@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func rateHeader(limit, remaining, reset string) http.Header {
+	h := http.Header{}
+	h.Set(headerRateLimit, limit)
+	h.Set(headerRateRemaining, remaining)
+	h.Set(headerRateReset, reset)
+	return h
+}
+
+func TestParseRate(t *testing.T) {
+	type want struct {
+		rate godo.Rate
+		ok   bool
+	}
+	cases := map[string]struct {
+		header http.Header
+		want   want
+	}{
+		"RateLimited": {
+			header: rateHeader("5000", "4816", "1444931833"),
+			want:   want{rate: godo.Rate{Limit: 5000, Remaining: 4816, Reset: godo.Timestamp{Time: time.Unix(1444931833, 0)}}, ok: true},
+		},
+		"NoHeaders": {
+			header: http.Header{},
+		},
+		"InvalidReset": {
+			header: rateHeader("5000", "4816", "soon"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rate, ok := ParseRate(tc.header)
+			if diff := cmp.Diff(tc.want, want{rate: rate, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("ParseRate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range rateHeader("5000", "4816", "1444931833") {
+			w.Header()[k] = v
+		}
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, "example")}
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	resp.Body.Close()
+
+	cases := map[string]struct {
+		got  float64
+		want float64
+	}{
+		"Limit":     {got: testutil.ToFloat64(rateLimitLimit.WithLabelValues("example")), want: 5000},
+		"Remaining": {got: testutil.ToFloat64(rateLimitRemaining.WithLabelValues("example")), want: 4816},
+		"Reset":     {got: testutil.ToFloat64(rateLimitReset.WithLabelValues("example")), want: 1444931833},
+	}
+	for name, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s: want %v, got %v", name, tc.want, tc.got)
+		}
+	}
+}
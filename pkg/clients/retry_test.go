@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// dropped is a status that makes the test server close the connection
+// without responding, which the client sees as a network error.
+const dropped = -1
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		method     string
+		maxRetries int
+		statuses   []int
+		wantStatus int
+		wantErr    bool
+		wantCalls  int
+	}{
+		"NoRetries": {
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		"RetriedUntilSuccess": {
+			method:     http.MethodGet,
+			maxRetries: 3,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		"RetriesExhausted": {
+			method:     http.MethodPut,
+			maxRetries: 1,
+			statuses:   []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  2,
+		},
+		"ClientErrorNotRetried": {
+			method:     http.MethodPut,
+			maxRetries: 3,
+			statuses:   []int{http.StatusUnprocessableEntity, http.StatusOK},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalls:  1,
+		},
+		"NetworkErrorRetried": {
+			method:     http.MethodDelete,
+			maxRetries: 3,
+			statuses:   []int{dropped, http.StatusNoContent},
+			wantStatus: http.StatusNoContent,
+			wantCalls:  2,
+		},
+		"PostRateLimitedRetried": {
+			method:     http.MethodPost,
+			maxRetries: 3,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusCreated},
+			wantStatus: http.StatusCreated,
+			wantCalls:  3,
+		},
+		"PostBadGatewayNotRetried": {
+			method:     http.MethodPost,
+			maxRetries: 3,
+			statuses:   []int{http.StatusBadGateway, http.StatusCreated},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  1,
+		},
+		"PostNetworkErrorNotRetried": {
+			method:     http.MethodPost,
+			maxRetries: 3,
+			statuses:   []int{dropped, http.StatusCreated},
+			wantErr:    true,
+			wantCalls:  1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Every attempt must send the whole body.
+				if b, _ := io.ReadAll(r.Body); string(b) != `{"name":"test"}` {
+					t.Errorf("attempt %d: unexpected body %q", calls, b)
+				}
+				status := tc.statuses[calls]
+				calls++
+				if status == dropped {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err != nil {
+						t.Fatal(err)
+					}
+					_ = conn.Close()
+					return
+				}
+				w.WriteHeader(status)
+			}))
+			defer srv.Close()
+
+			rt := newRetryTransport(srv.Client().Transport, tc.maxRetries)
+			rt.waitMin, rt.waitMax = 0, 0
+			req, err := http.NewRequest(tc.method, srv.URL, strings.NewReader(`{"name":"test"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := rt.RoundTrip(req)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("RoundTrip(...): -want error, +got error:\n%s", diff)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+				if diff := cmp.Diff(tc.wantStatus, resp.StatusCode); diff != "" {
+					t.Errorf("RoundTrip(...): -want status, +got status:\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.wantCalls, calls); diff != "" {
+				t.Errorf("RoundTrip(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	type want struct {
+		wait time.Duration
+		ok   bool
+	}
+	cases := map[string]struct {
+		header string
+		want   want
+	}{
+		"NotSet": {},
+		"Seconds": {
+			header: "3",
+			want:   want{wait: 3 * time.Second, ok: true},
+		},
+		"PastDate": {
+			header: "Mon, 02 Jan 2006 15:04:05 GMT",
+			want:   want{ok: true},
+		},
+		"Invalid": {
+			header: "soon",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+			wait, ok := retryAfter(resp)
+			if diff := cmp.Diff(tc.want, want{wait: wait, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("retryAfter(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
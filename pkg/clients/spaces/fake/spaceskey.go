@@ -0,0 +1,40 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/spaces"
+)
+
+// this ensures that the mock implements the client interface
+var _ spaces.KeyClient = (*MockKeyClient)(nil)
+
+// MockKeyClient is a type that implements all the methods for KeyClient interface
+type MockKeyClient struct {
+	MockGet    func(context.Context, string) (*spaces.Key, *godo.Response, error)
+	MockCreate func(context.Context, *spaces.KeyRequest) (*spaces.Key, *godo.Response, error)
+	MockUpdate func(context.Context, string, *spaces.KeyRequest) (*spaces.Key, *godo.Response, error)
+	MockDelete func(context.Context, string) (*godo.Response, error)
+}
+
+// Get mocks Get method
+func (c *MockKeyClient) Get(ctx context.Context, accessKey string) (*spaces.Key, *godo.Response, error) {
+	return c.MockGet(ctx, accessKey)
+}
+
+// Create mocks Create method
+func (c *MockKeyClient) Create(ctx context.Context, request *spaces.KeyRequest) (*spaces.Key, *godo.Response, error) {
+	return c.MockCreate(ctx, request)
+}
+
+// Update mocks Update method
+func (c *MockKeyClient) Update(ctx context.Context, accessKey string, request *spaces.KeyRequest) (*spaces.Key, *godo.Response, error) {
+	return c.MockUpdate(ctx, accessKey, request)
+}
+
+// Delete mocks Delete method
+func (c *MockKeyClient) Delete(ctx context.Context, accessKey string) (*godo.Response, error) {
+	return c.MockDelete(ctx, accessKey)
+}
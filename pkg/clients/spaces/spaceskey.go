@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/spaces/v1alpha1"
+)
+
+const (
+	keysPath = "/v2/spaces/keys"
+	keyPath  = "/v2/spaces/keys/%s"
+
+	errGrantBucketRequired = "a bucket is required for %q grants"
+	errGrantBucketExtra    = "%q grants apply to all buckets and must not name one"
+)
+
+// Connection secret keys of a DOSpacesKey.
+const (
+	ConnectionSecretAccessKeyIDKey     = "accessKeyId"
+	ConnectionSecretSecretAccessKeyKey = "secretAccessKey"
+)
+
+// Grant grants a Spaces key access to a bucket.
+type Grant struct {
+	Bucket     string `json:"bucket"`
+	Permission string `json:"permission"`
+}
+
+// Key is a Spaces access key. The secret key is only returned when the key is
+// created.
+type Key struct {
+	Name      string  `json:"name,omitempty"`
+	Grants    []Grant `json:"grants,omitempty"`
+	AccessKey string  `json:"access_key,omitempty"`
+	SecretKey string  `json:"secret_key,omitempty"`
+	CreatedAt string  `json:"created_at,omitempty"`
+}
+
+// KeyRequest is the request body used to create or update a Spaces key.
+type KeyRequest struct {
+	Name   string  `json:"name"`
+	Grants []Grant `json:"grants"`
+}
+
+// KeyClient is the external client used for DOSpacesKey Custom Resource. godo
+// does not support these endpoints yet.
+type KeyClient interface {
+	Get(context.Context, string) (*Key, *godo.Response, error)
+	Create(context.Context, *KeyRequest) (*Key, *godo.Response, error)
+	Update(context.Context, string, *KeyRequest) (*Key, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+}
+
+// NewKeyClient returns a KeyClient that issues requests through the supplied
+// godo.Client.
+func NewKeyClient(c *godo.Client) KeyClient {
+	return &keyClient{client: c}
+}
+
+type keyClient struct {
+	client *godo.Client
+}
+
+func (c *keyClient) Get(ctx context.Context, accessKey string) (*Key, *godo.Response, error) {
+	return c.do(ctx, http.MethodGet, fmt.Sprintf(keyPath, accessKey), nil)
+}
+
+func (c *keyClient) Create(ctx context.Context, kr *KeyRequest) (*Key, *godo.Response, error) {
+	return c.do(ctx, http.MethodPost, keysPath, kr)
+}
+
+func (c *keyClient) Update(ctx context.Context, accessKey string, kr *KeyRequest) (*Key, *godo.Response, error) {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf(keyPath, accessKey), kr)
+}
+
+func (c *keyClient) Delete(ctx context.Context, accessKey string) (*godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodDelete, fmt.Sprintf(keyPath, accessKey), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(ctx, req, nil)
+}
+
+func (c *keyClient) do(ctx context.Context, method, path string, body interface{}) (*Key, *godo.Response, error) {
+	req, err := c.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := new(struct {
+		Key *Key `json:"key"`
+	})
+	resp, err := c.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Key, resp, nil
+}
+
+// ValidateKey returns an error if a grant of the supplied
+// DOSpacesKeyParameters names a bucket when it must not, or the other way
+// around.
+func ValidateKey(p v1alpha1.DOSpacesKeyParameters) error {
+	for _, g := range p.Grants {
+		switch {
+		case g.Permission == v1alpha1.PermissionFullAccess && g.Bucket != "":
+			return errors.Errorf(errGrantBucketExtra, g.Permission)
+		case g.Permission != v1alpha1.PermissionFullAccess && g.Bucket == "":
+			return errors.Errorf(errGrantBucketRequired, g.Permission)
+		}
+	}
+	return nil
+}
+
+// GenerateKey generates *KeyRequest instance from DOSpacesKeyParameters.
+func GenerateKey(name string, p v1alpha1.DOSpacesKeyParameters) *KeyRequest {
+	grants := make([]Grant, len(p.Grants))
+	for i, g := range p.Grants {
+		grants[i] = Grant{Bucket: g.Bucket, Permission: g.Permission}
+	}
+	return &KeyRequest{Name: name, Grants: grants}
+}
+
+// IsUpToDate returns true if the grants of the supplied observed Key match the
+// supplied DOSpacesKeyParameters, regardless of their order.
+func IsUpToDate(p v1alpha1.DOSpacesKeyParameters, observed Key) bool {
+	desired := GenerateKey("", p).Grants
+	if len(desired) != len(observed.Grants) {
+		return false
+	}
+	observedGrants := append([]Grant(nil), observed.Grants...)
+	sortGrants(desired)
+	sortGrants(observedGrants)
+	for i := range desired {
+		if desired[i] != observedGrants[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortGrants(grants []Grant) {
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].Bucket != grants[j].Bucket {
+			return grants[i].Bucket < grants[j].Bucket
+		}
+		return grants[i].Permission < grants[j].Permission
+	})
+}
+
+// GenerateConnectionDetails generates the managed.ConnectionDetails of the
+// supplied Key. The secret key is only known when the key is created.
+func GenerateConnectionDetails(key *Key) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if key == nil {
+		return cd
+	}
+	if key.AccessKey != "" {
+		cd[ConnectionSecretAccessKeyIDKey] = []byte(key.AccessKey)
+	}
+	if key.SecretKey != "" {
+		cd[ConnectionSecretSecretAccessKeyKey] = []byte(key.SecretKey)
+	}
+	return cd
+}
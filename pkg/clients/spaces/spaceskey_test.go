@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spaces
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/spaces/v1alpha1"
+)
+
+func TestValidateKey(t *testing.T) {
+	cases := map[string]struct {
+		grants []v1alpha1.DOSpacesKeyGrant
+		want   error
+	}{
+		"BucketGrants": {
+			grants: []v1alpha1.DOSpacesKeyGrant{
+				{Bucket: "uploads", Permission: v1alpha1.PermissionReadWrite},
+				{Bucket: "assets", Permission: v1alpha1.PermissionRead},
+			},
+		},
+		"FullAccess": {
+			grants: []v1alpha1.DOSpacesKeyGrant{{Permission: v1alpha1.PermissionFullAccess}},
+		},
+		"FullAccessWithBucket": {
+			grants: []v1alpha1.DOSpacesKeyGrant{{Bucket: "uploads", Permission: v1alpha1.PermissionFullAccess}},
+			want:   errors.Errorf(errGrantBucketExtra, v1alpha1.PermissionFullAccess),
+		},
+		"ReadWithoutBucket": {
+			grants: []v1alpha1.DOSpacesKeyGrant{{Permission: v1alpha1.PermissionRead}},
+			want:   errors.Errorf(errGrantBucketRequired, v1alpha1.PermissionRead),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateKey(v1alpha1.DOSpacesKeyParameters{Grants: tc.grants})
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateKey(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	p := v1alpha1.DOSpacesKeyParameters{Grants: []v1alpha1.DOSpacesKeyGrant{
+		{Bucket: "uploads", Permission: v1alpha1.PermissionReadWrite},
+		{Bucket: "assets", Permission: v1alpha1.PermissionRead},
+	}}
+
+	cases := map[string]struct {
+		observed Key
+		want     bool
+	}{
+		"SameGrantsInOtherOrder": {
+			observed: Key{Grants: []Grant{
+				{Bucket: "assets", Permission: v1alpha1.PermissionRead},
+				{Bucket: "uploads", Permission: v1alpha1.PermissionReadWrite},
+			}},
+			want: true,
+		},
+		"PermissionChanged": {
+			observed: Key{Grants: []Grant{
+				{Bucket: "assets", Permission: v1alpha1.PermissionReadWrite},
+				{Bucket: "uploads", Permission: v1alpha1.PermissionReadWrite},
+			}},
+			want: false,
+		},
+		"GrantMissing": {
+			observed: Key{Grants: []Grant{{Bucket: "uploads", Permission: v1alpha1.PermissionReadWrite}}},
+			want:     false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsUpToDate(p, tc.observed); got != tc.want {
+				t.Errorf("IsUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
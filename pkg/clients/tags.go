@@ -0,0 +1,274 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// AnnotationKeyManagedTags records the tags the provider applied to an
+	// external resource, so that only those are removed when they are no
+	// longer desired.
+	AnnotationKeyManagedTags = "do.crossplane.io/managed-tags"
+
+	// MaxTagLength is the maximum length of a tag name.
+	MaxTagLength = 255
+
+	errTagResources   = "cannot tag resources with tag %q"
+	errCreateTag      = "cannot create tag %q"
+	errUntagResources = "cannot untag resources with tag %q"
+	errTagEmpty       = "tag names must not be empty"
+	errTagTooLong     = "tag %q is longer than %d characters"
+	errTagInvalid     = "tag %q may only contain letters, numbers, colons, dashes and underscores"
+)
+
+// tagName matches the tag names DigitalOcean accepts.
+var tagName = regexp.MustCompile(`^[a-zA-Z0-9:_-]+$`)
+
+// ValidateTags returns an error naming the first of the supplied tags that
+// DigitalOcean would reject. DigitalOcean only rejects them once a resource
+// is created or tagged.
+func ValidateTags(tags []string) error {
+	for _, t := range tags {
+		switch {
+		case t == "":
+			return errors.New(errTagEmpty)
+		case len(t) > MaxTagLength:
+			return errors.Errorf(errTagTooLong, t, MaxTagLength)
+		case !tagName.MatchString(t):
+			return errors.Errorf(errTagInvalid, t)
+		}
+	}
+	return nil
+}
+
+// TagsClient is the subset of godo.TagsService used to tag resources.
+type TagsClient interface {
+	TagResources(context.Context, string, *godo.TagResourcesRequest) (*godo.Response, error)
+}
+
+// TagCreator is the subset of godo.TagsService used to create tags.
+type TagCreator interface {
+	Create(context.Context, *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error)
+}
+
+// EnsuringTagsClient is the subset of godo.TagsService used to tag resources
+// with tags that may not exist yet.
+type EnsuringTagsClient interface {
+	TagsClient
+	TagCreator
+}
+
+// ResourceTagsClient is the subset of godo.TagsService used to reconcile the
+// tags of a resource.
+type ResourceTagsClient interface {
+	EnsuringTagsClient
+	UntagResources(context.Context, string, *godo.UntagResourcesRequest) (*godo.Response, error)
+}
+
+// EnsureTags creates the supplied tags, so that resources can be tagged with
+// them. DigitalOcean responds with a 404 when tagging resources with a tag
+// that doesn't exist yet, while creating a tag that already exists succeeds.
+func EnsureTags(ctx context.Context, c TagCreator, tags []string) error {
+	for _, t := range tags {
+		if _, _, err := c.Create(ctx, &godo.TagCreateRequest{Name: t}); err != nil {
+			return errors.Wrapf(err, errCreateTag, t)
+		}
+	}
+	return nil
+}
+
+// GetManagedTags returns the tags the provider applied to the external
+// resource of the supplied managed resource.
+func GetManagedTags(mg resource.Managed) []string {
+	return GetTagsAnnotation(mg, AnnotationKeyManagedTags)
+}
+
+// SetManagedTags records the tags the provider applied to the external
+// resource of the supplied managed resource.
+func SetManagedTags(mg resource.Managed, tags []string) {
+	SetTagsAnnotation(mg, AnnotationKeyManagedTags, tags)
+}
+
+// GetTagsAnnotation returns the tags recorded in the supplied annotation of
+// the supplied managed resource.
+func GetTagsAnnotation(mg resource.Managed, key string) []string {
+	v := mg.GetAnnotations()[key]
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// SetTagsAnnotation records the supplied tags in the supplied annotation of
+// the supplied managed resource, removing it if there are none. Tag names
+// can't contain commas.
+func SetTagsAnnotation(mg resource.Managed, key string, tags []string) {
+	if len(tags) == 0 {
+		meta.RemoveAnnotations(mg, key)
+		return
+	}
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+	meta.AddAnnotations(mg, map[string]string{key: strings.Join(sorted, ",")})
+}
+
+// IgnoreTag returns true if the supplied tag matches one of the supplied
+// patterns. A pattern matches a tag exactly, or, if it ends with "*", any tag
+// it is a prefix of. Tag names can't contain "*".
+func IgnoreTag(tag string, ignore []string) bool {
+	for _, p := range ignore {
+		if prefix := strings.TrimSuffix(p, "*"); prefix != p {
+			if strings.HasPrefix(tag, prefix) {
+				return true
+			}
+			continue
+		}
+		if tag == p {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterIgnoredTags returns the supplied tags that don't match any of the
+// supplied patterns.
+func FilterIgnoredTags(tags, ignore []string) []string {
+	if len(ignore) == 0 {
+		return tags
+	}
+	var out []string
+	for _, t := range tags {
+		if !IgnoreTag(t, ignore) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// DiffTags returns the desired tags that are missing from the observed tags,
+// and the observed tags that were previously managed but are no longer
+// desired. Observed tags that were never managed are left alone, so tags
+// added outside of the provider are not removed. Tags matching one of the
+// ignored patterns are neither added nor removed.
+func DiffTags(desired, managed, observed, ignore []string) (add, remove []string) {
+	desired = FilterIgnoredTags(desired, ignore)
+	want := toSet(desired)
+	have := toSet(observed)
+	for _, t := range desired {
+		if !have[t] {
+			add = append(add, t)
+		}
+	}
+	for t := range toSet(FilterIgnoredTags(managed, ignore)) {
+		if !want[t] && have[t] {
+			remove = append(remove, t)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+// ManagedTagsUpToDate returns true if the tags recorded as managed on the
+// supplied managed resource are the supplied desired tags.
+func ManagedTagsUpToDate(mg resource.Managed, desired []string) bool {
+	managed := toSet(GetManagedTags(mg))
+	want := toSet(desired)
+	if len(managed) != len(want) {
+		return false
+	}
+	for t := range want {
+		if !managed[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// A TagDiff describes how the tags of an external resource differ from the
+// ones the provider manages on it.
+type TagDiff struct {
+	// Managed are the desired tags that don't match an ignored pattern.
+	Managed []string
+
+	// Add are the managed tags the external resource is missing.
+	Add []string
+
+	// Remove are the previously managed tags the external resource still
+	// has but should no longer have.
+	Remove []string
+}
+
+// DiffManagedTags returns how the supplied observed tags of the external
+// resource of the supplied managed resource differ from the supplied desired
+// tags. Tags matching one of the ignored patterns are neither managed, added
+// nor removed.
+func DiffManagedTags(mg resource.Managed, desired, observed, ignore []string) TagDiff {
+	managed := FilterIgnoredTags(desired, ignore)
+	add, remove := DiffTags(managed, GetManagedTags(mg), observed, ignore)
+	return TagDiff{Managed: managed, Add: add, Remove: remove}
+}
+
+// Recorded returns true if the managed tags are the ones recorded on the
+// supplied managed resource.
+func (d TagDiff) Recorded(mg resource.Managed) bool {
+	return ManagedTagsUpToDate(mg, d.Managed)
+}
+
+// UpToDate returns true if no tags have to be added or removed, and the
+// managed tags are recorded on the supplied managed resource.
+func (d TagDiff) UpToDate(mg resource.Managed) bool {
+	return len(d.Add) == 0 && len(d.Remove) == 0 && d.Recorded(mg)
+}
+
+// UpdateTags tags the supplied resource with the tags to add, creating them if
+// they don't exist yet, and untags it from the tags to remove.
+func UpdateTags(ctx context.Context, c ResourceTagsClient, r godo.Resource, add, remove []string) error {
+	for _, t := range add {
+		if err := EnsureTags(ctx, c, []string{t}); err != nil {
+			return err
+		}
+		if _, err := c.TagResources(ctx, t, &godo.TagResourcesRequest{Resources: []godo.Resource{r}}); err != nil {
+			return errors.Wrapf(err, errTagResources, t)
+		}
+	}
+	for _, t := range remove {
+		if _, err := c.UntagResources(ctx, t, &godo.UntagResourcesRequest{Resources: []godo.Resource{r}}); err != nil {
+			return errors.Wrapf(err, errUntagResources, t)
+		}
+	}
+	return nil
+}
+
+func toSet(s []string) map[string]bool {
+	m := make(map[string]bool, len(s))
+	for _, v := range s {
+		m[v] = true
+	}
+	return m
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type mockEnsuringTagsClient struct {
+	ops       []string
+	createErr error
+}
+
+func (c *mockEnsuringTagsClient) Create(_ context.Context, req *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error) {
+	if c.createErr != nil {
+		return nil, nil, c.createErr
+	}
+	c.ops = append(c.ops, "create "+req.Name)
+	return &godo.Tag{Name: req.Name}, &godo.Response{}, nil
+}
+
+func (c *mockEnsuringTagsClient) TagResources(_ context.Context, tag string, req *godo.TagResourcesRequest) (*godo.Response, error) {
+	c.ops = append(c.ops, "tag "+tag+" "+strconv.Itoa(len(req.Resources)))
+	return &godo.Response{}, nil
+}
+
+func (c *mockEnsuringTagsClient) UntagResources(_ context.Context, tag string, req *godo.UntagResourcesRequest) (*godo.Response, error) {
+	c.ops = append(c.ops, "untag "+tag+" "+strconv.Itoa(len(req.Resources)))
+	return &godo.Response{}, nil
+}
+
+func TestUpdateTags(t *testing.T) {
+	errBoom := errors.New("boom")
+	r := godo.Resource{ID: "1", Type: godo.DropletResourceType}
+
+	type want struct {
+		ops []string
+		err error
+	}
+	cases := map[string]struct {
+		createErr error
+		add       []string
+		remove    []string
+		want
+	}{
+		"AddsAndRemoves": {
+			add:    []string{"prod", "web"},
+			remove: []string{"staging"},
+			want: want{
+				ops: []string{"create prod", "tag prod 1", "create web", "tag web 1", "untag staging 1"},
+			},
+		},
+		"NothingToDo": {},
+		"CreateFailed": {
+			createErr: errBoom,
+			add:       []string{"prod"},
+			remove:    []string{"staging"},
+			want: want{
+				err: errors.Wrapf(errBoom, errCreateTag, "prod"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &mockEnsuringTagsClient{createErr: tc.createErr}
+			err := UpdateTags(context.Background(), c, r, tc.add, tc.remove)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("UpdateTags(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ops, c.ops); diff != "" {
+				t.Errorf("UpdateTags(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	type want struct {
+		add    []string
+		remove []string
+	}
+	cases := map[string]struct {
+		desired  []string
+		managed  []string
+		observed []string
+		ignore   []string
+		want     want
+	}{
+		"InSync": {
+			desired:  []string{"a", "b"},
+			managed:  []string{"a", "b"},
+			observed: []string{"b", "a"},
+		},
+		"Add": {
+			desired:  []string{"a", "b"},
+			managed:  []string{"a"},
+			observed: []string{"a"},
+			want:     want{add: []string{"b"}},
+		},
+		"Remove": {
+			desired:  []string{"a"},
+			managed:  []string{"a", "b"},
+			observed: []string{"a", "b"},
+			want:     want{remove: []string{"b"}},
+		},
+		"Mixed": {
+			desired:  []string{"a", "c"},
+			managed:  []string{"a", "b"},
+			observed: []string{"a", "b", "external"},
+			want:     want{add: []string{"c"}, remove: []string{"b"}},
+		},
+		"KeepsExternalTags": {
+			managed:  []string{"a"},
+			observed: []string{"a", "external"},
+			want:     want{remove: []string{"a"}},
+		},
+		"AlreadyRemoved": {
+			managed:  []string{"a"},
+			observed: []string{"external"},
+		},
+		"IgnoresBillingTags": {
+			desired:  []string{"a", "billing:team-b"},
+			managed:  []string{"a", "billing:team-a"},
+			observed: []string{"a", "billing:team-a"},
+			ignore:   []string{"billing:*"},
+		},
+		"IgnoresExactTags": {
+			desired:  []string{"b"},
+			managed:  []string{"a", "billing"},
+			observed: []string{"a", "billing"},
+			ignore:   []string{"billing"},
+			want:     want{add: []string{"b"}, remove: []string{"a"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			add, remove := DiffTags(tc.desired, tc.managed, tc.observed, tc.ignore)
+			if diff := cmp.Diff(tc.want.add, add); diff != "" {
+				t.Errorf("DiffTags(...): -want add, +got add:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("DiffTags(...): -want remove, +got remove:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffManagedTags(t *testing.T) {
+	type want struct {
+		diff     TagDiff
+		recorded bool
+		upToDate bool
+	}
+	cases := map[string]struct {
+		desired  []string
+		managed  []string
+		observed []string
+		ignore   []string
+		want     want
+	}{
+		"UpToDate": {
+			desired:  []string{"web", "prod"},
+			managed:  []string{"prod", "web"},
+			observed: []string{"prod", "web", "k8s:abc"},
+			want:     want{diff: TagDiff{Managed: []string{"web", "prod"}}, recorded: true, upToDate: true},
+		},
+		"NotRecorded": {
+			desired:  []string{"web"},
+			observed: []string{"web"},
+			want:     want{diff: TagDiff{Managed: []string{"web"}}},
+		},
+		"AddsAndRemoves": {
+			desired:  []string{"web"},
+			managed:  []string{"staging"},
+			observed: []string{"staging"},
+			want:     want{diff: TagDiff{Managed: []string{"web"}, Add: []string{"web"}, Remove: []string{"staging"}}},
+		},
+		"IgnoresTags": {
+			desired:  []string{"web", "k8s:abc"},
+			managed:  []string{"web"},
+			observed: []string{"web"},
+			ignore:   []string{"k8s:*"},
+			want:     want{diff: TagDiff{Managed: []string{"web"}}, recorded: true, upToDate: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			SetManagedTags(mg, tc.managed)
+			d := DiffManagedTags(mg, tc.desired, tc.observed, tc.ignore)
+			if diff := cmp.Diff(tc.want.diff, d); diff != "" {
+				t.Errorf("DiffManagedTags(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.recorded, d.Recorded(mg)); diff != "" {
+				t.Errorf("Recorded(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, d.UpToDate(mg)); diff != "" {
+				t.Errorf("UpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilterIgnoredTags(t *testing.T) {
+	cases := map[string]struct {
+		tags   []string
+		ignore []string
+		want   []string
+	}{
+		"NoPatterns": {
+			tags: []string{"a", "billing:team-a"},
+			want: []string{"a", "billing:team-a"},
+		},
+		"Prefix": {
+			tags:   []string{"a", "billing:team-a", "billing:team-b", "billing"},
+			ignore: []string{"billing:*"},
+			want:   []string{"a", "billing"},
+		},
+		"Exact": {
+			tags:   []string{"a", "billing:team-a", "billing"},
+			ignore: []string{"billing"},
+			want:   []string{"a", "billing:team-a"},
+		},
+		"AllIgnored": {
+			tags:   []string{"billing:team-a"},
+			ignore: []string{"*"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, FilterIgnoredTags(tc.tags, tc.ignore)); diff != "" {
+				t.Errorf("FilterIgnoredTags(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	long := strings.Repeat("a", MaxTagLength+1)
+
+	cases := map[string]struct {
+		tags []string
+		want error
+	}{
+		"NoTags": {},
+		"Valid": {
+			tags: []string{"prod", "team:data", "k8s-worker_1", strings.Repeat("a", MaxTagLength)},
+		},
+		"Empty": {
+			tags: []string{"prod", ""},
+			want: errors.New(errTagEmpty),
+		},
+		"TooLong": {
+			tags: []string{long},
+			want: errors.Errorf(errTagTooLong, long, MaxTagLength),
+		},
+		"Space": {
+			tags: []string{"prod", "team data"},
+			want: errors.Errorf(errTagInvalid, "team data"),
+		},
+		"Slash": {
+			tags: []string{"team/data"},
+			want: errors.Errorf(errTagInvalid, "team/data"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ValidateTags(tc.tags), test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateTags(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
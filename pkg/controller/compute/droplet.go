@@ -18,9 +18,9 @@ package compute
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/digitalocean/godo"
-	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +35,7 @@ import (
 	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	docompute "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/compute"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
 )
 
 const (
@@ -45,11 +46,19 @@ const (
 	errDropletCreateFailed = "creation of Droplet resource has failed"
 	errDropletDeleteFailed = "deletion of Droplet resource has failed"
 	errDropletUpdate       = "cannot update managed Droplet resource"
+
+	errGetDestroyStatus  = "cannot get the status of destroying Droplet with its associated resources"
+	errDestroyAssociated = "destruction of the associated resources of Droplet has failed for %d resources"
+
+	errGetBackupPolicy    = "cannot get the backup policy of Droplet"
+	errChangeBackupPolicy = "cannot change the backup policy of Droplet"
+	errEnableBackups      = "cannot enable backups of Droplet"
+	errDropletTags        = "cannot update the tags of Droplet"
 )
 
 // SetupDroplet adds a controller that reconciles Droplet managed
 // resources.
-func SetupDroplet(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDroplet(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.DropletGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -57,30 +66,48 @@ func SetupDroplet(mgr ctrl.Manager, l logging.Logger) error {
 		For(&v1alpha1.Droplet{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DropletGroupVersionKind),
-			managed.WithExternalConnecter(&dropletConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(do.NewObserveOnlyConnecter(&dropletConnector{kube: mgr.GetClient(), readOnlySpec: o.ReadOnlySpec, skipAvailabilityCheck: o.SkipAvailabilityChecks}, o.ObserveOnly)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithConnectionPublishers(),
-			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient())),
+			managed.WithConnectionPublishers(do.NewChecksumPublisher(managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), do.NewDefaultConnectionSecretNamespace(mgr.GetClient(), o.ConnectionSecretNamespace)),
+			managed.WithFinalizer(resource.NewAPIFinalizer(mgr.GetClient(), o.Finalizer)),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
 type dropletConnector struct {
-	kube client.Client
+	kube                  client.Client
+	readOnlySpec          bool
+	skipAvailabilityCheck bool
 }
 
 func (c *dropletConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	client, pc, err := do.ConnectWithConfig(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
-	return &dropletExternal{Client: client, kube: c.kube}, nil
+	return &dropletExternal{Client: client, allowedRegions: pc.AllowedRegions, tags: client.Tags, backups: docompute.NewBackupPolicyClient(client), associated: docompute.NewAssociatedResourcesClient(client), kube: c.kube, readOnlySpec: c.readOnlySpec, skipAvailabilityCheck: c.skipAvailabilityCheck}, nil
 }
 
 type dropletExternal struct {
-	kube client.Client
+	kube       client.Client
+	tags       do.ResourceTagsClient
+	backups    docompute.BackupPolicyClient
+	associated docompute.AssociatedResourcesClient
 	*godo.Client
+	readOnlySpec bool
+
+	// allowedRegions are the regions the ProviderConfig allows Droplets to
+	// be created in. All are allowed if it is empty.
+	allowedRegions []string
+
+	skipAvailabilityCheck bool
+}
+
+// dropletConditions maps the statuses of a Droplet to the conditions they result in.
+var dropletConditions = do.StatusConditions{
+	v1alpha1.StatusNew:    xpv1.Creating,
+	v1alpha1.StatusActive: xpv1.Available,
 }
 
 func (c *dropletExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -93,13 +120,32 @@ func (c *dropletExternal) Observe(ctx context.Context, mg resource.Managed) (man
 			ResourceExists: false,
 		}, nil
 	}
+	// The Droplet is reported to exist until its associated resources were
+	// destroyed too, so that Delete keeps polling the destroy status.
+	if meta.WasDeleted(cr) && do.BoolValue(cr.Spec.ForProvider.DeleteAssociatedResources) {
+		status, response, err := c.associated.GetDestroyStatus(ctx, cr.Status.AtProvider.ID)
+		if do.IgnoreNotFound(err, response) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetDestroyStatus)
+		}
+		if status != nil && (!status.Completed() || status.Failures > 0) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+	}
 	observed, response, err := c.Droplets.Get(ctx, cr.Status.AtProvider.ID)
 	if err != nil {
+		if do.IsRetryable(response, err) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetDroplet)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
-	docompute.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	lateInit := cr.Spec.ForProvider.DeepCopy()
+	docompute.LateInitializeSpec(lateInit, *observed)
+	// The spec is written before the status is, as writing either refreshes
+	// the whole object.
+	if err := do.PersistLateInit(ctx, c.kube, cr, &cr.Spec.ForProvider, lateInit, !c.readOnlySpec); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDropletUpdate)
+	}
 	observedPrivateIPv4, _ := observed.PrivateIPv4()
 	observedPublicIPv4, _ := observed.PublicIPv4()
 
@@ -108,34 +154,56 @@ func (c *dropletExternal) Observe(ctx context.Context, mg resource.Managed) (man
 		ID:                observed.ID,
 		PrivateIPv4:       observedPrivateIPv4,
 		PublicIPv4:        observedPublicIPv4,
-		Region:            observed.Region.Slug,
 		Size:              observed.SizeSlug,
 		Status:            observed.Status,
+		Backups:           docompute.HasFeature(*observed, docompute.FeatureBackups),
+		Monitoring:        docompute.HasFeature(*observed, docompute.FeatureMonitoring),
+	}
+	if observed.Region != nil {
+		cr.Status.AtProvider.Region = observed.Region.Slug
+	}
+	if do.BoolValue(cr.Spec.ForProvider.OneClick) {
+		cr.Status.AtProvider.OneClickApp = cr.Spec.ForProvider.Image
 	}
 	if err := c.kube.Status().Update(ctx, cr); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errDropletUpdate)
 	}
 
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errDropletUpdate)
-		}
-	}
+	do.SetStatusCondition(cr, cr.Status.AtProvider.Status, dropletConditions)
 
-	switch cr.Status.AtProvider.Status {
-	case v1alpha1.StatusNew:
-		cr.SetConditions(xpv1.Creating())
-	case v1alpha1.StatusActive:
-		cr.SetConditions(xpv1.Available())
+	// Only the tags, the backups and the backup policy of a Droplet can be
+	// updated.
+	upToDate, err := c.isBackupPolicyUpToDate(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
+	tagsUpToDate := do.DiffManagedTags(cr, cr.Spec.ForProvider.Tags, observed.Tags, cr.Spec.ForProvider.IgnoreTagsMatching).UpToDate(cr)
 
-	// Droplets are always "up to date" because they can't be updated. ¯\_(ツ)_/¯
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate && tagsUpToDate && !docompute.NeedsBackups(cr.Spec.ForProvider, cr.Status.AtProvider),
+		ConnectionDetails: docompute.GenerateConnectionDetails(*observed),
 	}, nil
 }
 
+// isBackupPolicyUpToDate returns true if the backup policy of the Droplet
+// matches the desired one. Invalid policies are reported as an error before
+// the observed policy is fetched.
+func (c *dropletExternal) isBackupPolicyUpToDate(ctx context.Context, cr *v1alpha1.Droplet) (bool, error) {
+	bp := cr.Spec.ForProvider.BackupPolicy
+	if bp == nil || cr.Status.AtProvider.Status != v1alpha1.StatusActive {
+		return true, nil
+	}
+	if err := docompute.ValidateBackupPolicy(cr.Spec.ForProvider); err != nil {
+		return false, errors.Wrap(err, errChangeBackupPolicy)
+	}
+	observed, _, err := c.backups.GetBackupPolicy(ctx, cr.Status.AtProvider.ID)
+	if err != nil {
+		return false, errors.Wrap(err, errGetBackupPolicy)
+	}
+	return docompute.IsBackupPolicyUpToDate(*bp, observed.BackupPolicy), nil
+}
+
 func (c *dropletExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.Droplet)
 	if !ok {
@@ -146,6 +214,30 @@ func (c *dropletExternal) Create(ctx context.Context, mg resource.Managed) (mana
 
 	name := meta.GetExternalName(cr)
 
+	if do.BoolValue(cr.Spec.ForProvider.OneClick) {
+		if err := docompute.ValidateOneClickApp(ctx, c.OneClick, cr.Spec.ForProvider.Image); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errDropletCreateFailed)
+		}
+	}
+
+	if err := do.ValidateTags(cr.Spec.ForProvider.Tags); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDropletCreateFailed)
+	}
+
+	if err := do.ValidateRegion(cr.Spec.ForProvider.Region, c.allowedRegions); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDropletCreateFailed)
+	}
+
+	if err := docompute.ValidateVPCRegion(ctx, c.VPCs, cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDropletCreateFailed)
+	}
+
+	if !c.skipAvailabilityCheck {
+		if err := docompute.ValidateSizeRegion(ctx, c.Sizes, cr.Spec.ForProvider); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errDropletCreateFailed)
+		}
+	}
+
 	create := &godo.DropletCreateRequest{}
 	docompute.GenerateDroplet(name, cr.Spec.ForProvider, create)
 
@@ -159,6 +251,9 @@ func (c *dropletExternal) Create(ctx context.Context, mg resource.Managed) (mana
 		ID:                droplet.ID,
 		Status:            droplet.Status,
 	}
+	if do.BoolValue(cr.Spec.ForProvider.OneClick) {
+		cr.Status.AtProvider.OneClickApp = cr.Spec.ForProvider.Image
+	}
 
 	if err := c.kube.Status().Update(ctx, cr); err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errDropletUpdate)
@@ -168,8 +263,52 @@ func (c *dropletExternal) Create(ctx context.Context, mg resource.Managed) (mana
 }
 
 func (c *dropletExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Droplets cannot be updated.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha1.Droplet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDroplet)
+	}
+
+	// Droplets can't be updated, apart from their tags, backups and backup
+	// policy. The backup policy is changed at a later reconcile, once backups
+	// were enabled.
+	if docompute.NeedsBackups(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		_, _, err := c.DropletActions.EnableBackups(ctx, cr.Status.AtProvider.ID)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errEnableBackups)
+	}
+
+	observed, _, err := c.Droplets.Get(ctx, cr.Status.AtProvider.ID)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetDroplet)
+	}
+	if err := c.updateTags(ctx, cr, *observed); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	bp := cr.Spec.ForProvider.BackupPolicy
+	if bp == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	if err := docompute.ValidateBackupPolicy(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errChangeBackupPolicy)
+	}
+	_, _, err = c.backups.ChangeBackupPolicy(ctx, cr.Status.AtProvider.ID, docompute.GenerateBackupPolicy(*bp))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errChangeBackupPolicy)
+}
+
+// updateTags reconciles the tags of the supplied observed Droplet, then
+// records the desired tags as the ones the provider manages. Tags that were
+// applied outside of the provider are kept.
+func (c *dropletExternal) updateTags(ctx context.Context, cr *v1alpha1.Droplet, observed godo.Droplet) error {
+	d := do.DiffManagedTags(cr, cr.Spec.ForProvider.Tags, observed.Tags, cr.Spec.ForProvider.IgnoreTagsMatching)
+	r := godo.Resource{ID: strconv.Itoa(observed.ID), Type: godo.DropletResourceType}
+	if err := do.UpdateTags(ctx, c.tags, r, d.Add, d.Remove); err != nil {
+		return errors.Wrap(err, errDropletTags)
+	}
+	if d.Recorded(cr) {
+		return nil
+	}
+	do.SetManagedTags(cr, d.Managed)
+	return errors.Wrap(c.kube.Update(ctx, cr), errDropletUpdate)
 }
 
 func (c *dropletExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -180,6 +319,29 @@ func (c *dropletExternal) Delete(ctx context.Context, mg resource.Managed) error
 
 	cr.Status.SetConditions(xpv1.Deleting())
 
+	if do.BoolValue(cr.Spec.ForProvider.DeleteAssociatedResources) {
+		return c.destroyWithAssociatedResources(ctx, cr)
+	}
+
 	response, err := c.Droplets.Delete(ctx, cr.Status.AtProvider.ID)
 	return errors.Wrap(do.IgnoreNotFound(err, response), errDropletDeleteFailed)
 }
+
+// destroyWithAssociatedResources starts destroying the Droplet along with its
+// associated resources unless that is already in progress. DigitalOcean
+// destroys them asynchronously, so Delete is called until Observe no longer
+// reports the Droplet.
+func (c *dropletExternal) destroyWithAssociatedResources(ctx context.Context, cr *v1alpha1.Droplet) error {
+	status, response, err := c.associated.GetDestroyStatus(ctx, cr.Status.AtProvider.ID)
+	if do.IgnoreNotFound(err, response) != nil {
+		return errors.Wrap(err, errGetDestroyStatus)
+	}
+	if status != nil {
+		if status.Completed() && status.Failures > 0 {
+			return errors.Errorf(errDestroyAssociated, status.Failures)
+		}
+		return nil
+	}
+	response, err = c.associated.DestroyWithAssociatedResources(ctx, cr.Status.AtProvider.ID)
+	return errors.Wrap(do.IgnoreNotFound(err, response), errDropletDeleteFailed)
+}
@@ -16,4 +16,286 @@ limitations under the License.
 
 package compute
 
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	docompute "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/compute"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/compute/fake"
+	dofake "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
 // TODO(khos2ow): Stop procrastinating!!
+
+const dropletID = 42
+
+// mockDroplets implements the Droplet methods the tests call. Any other
+// method panics.
+type mockDroplets struct {
+	godo.DropletsService
+	MockGet    func(context.Context, int) (*godo.Droplet, *godo.Response, error)
+	MockDelete func(context.Context, int) (*godo.Response, error)
+}
+
+func (m *mockDroplets) Get(ctx context.Context, id int) (*godo.Droplet, *godo.Response, error) {
+	return m.MockGet(ctx, id)
+}
+
+func (m *mockDroplets) Delete(ctx context.Context, id int) (*godo.Response, error) {
+	return m.MockDelete(ctx, id)
+}
+
+func droplet(deleteAssociated *bool) *v1alpha1.Droplet {
+	cr := &v1alpha1.Droplet{}
+	cr.Spec.ForProvider.DeleteAssociatedResources = deleteAssociated
+	cr.Status.AtProvider.ID = dropletID
+	return cr
+}
+
+func Test_dropletExternal_Delete(t *testing.T) {
+	resp := func(code int) *godo.Response { return &godo.Response{Response: &http.Response{StatusCode: code}} }
+	deleted := func(context.Context, int) (*godo.Response, error) { return resp(http.StatusNoContent), nil }
+	notStarted := func(context.Context, int) (*docompute.DestroyStatus, *godo.Response, error) {
+		return nil, resp(http.StatusNotFound), errors.New("not found")
+	}
+	withStatus := func(s docompute.DestroyStatus) func(context.Context, int) (*docompute.DestroyStatus, *godo.Response, error) {
+		return func(context.Context, int) (*docompute.DestroyStatus, *godo.Response, error) {
+			return &s, resp(http.StatusOK), nil
+		}
+	}
+
+	type want struct {
+		deleted   bool
+		destroyed bool
+		err       error
+	}
+	tests := map[string]struct {
+		cr     *v1alpha1.Droplet
+		status func(context.Context, int) (*docompute.DestroyStatus, *godo.Response, error)
+		want   want
+	}{
+		"KeepsAssociatedResourcesByDefault": {
+			cr:   droplet(nil),
+			want: want{deleted: true},
+		},
+		"KeepsAssociatedResources": {
+			cr:   droplet(godo.PtrTo(false)),
+			want: want{deleted: true},
+		},
+		"DestroysAssociatedResources": {
+			cr:     droplet(godo.PtrTo(true)),
+			status: notStarted,
+			want:   want{destroyed: true},
+		},
+		"DestroyInProgress": {
+			cr:     droplet(godo.PtrTo(true)),
+			status: withStatus(docompute.DestroyStatus{}),
+			want:   want{},
+		},
+		"DestroyFailed": {
+			cr:     droplet(godo.PtrTo(true)),
+			status: withStatus(docompute.DestroyStatus{CompletedAt: "2021-01-01T00:00:00Z", Failures: 2}),
+			want:   want{err: errors.Errorf(errDestroyAssociated, 2)},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			e := &dropletExternal{
+				Client: &godo.Client{Droplets: &mockDroplets{MockDelete: func(ctx context.Context, id int) (*godo.Response, error) {
+					got.deleted = true
+					return deleted(ctx, id)
+				}}},
+				associated: &fake.MockAssociatedResourcesClient{
+					MockGetDestroyStatus: tc.status,
+					MockDestroyWithAssociatedResources: func(ctx context.Context, id int) (*godo.Response, error) {
+						got.destroyed = true
+						return deleted(ctx, id)
+					},
+				},
+			}
+			got.err = e.Delete(context.Background(), tc.cr)
+
+			if diff := cmp.Diff(tc.want.err, got.err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, got.deleted); diff != "" {
+				t.Errorf("Delete(...): -want deleted, +got deleted:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.destroyed, got.destroyed); diff != "" {
+				t.Errorf("Delete(...): -want destroyed with associated resources, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// mockDropletActions implements the Droplet action methods the tests call.
+// Any other method panics.
+type mockDropletActions struct {
+	godo.DropletActionsService
+	MockEnableBackups func(context.Context, int) (*godo.Action, *godo.Response, error)
+}
+
+func (m *mockDropletActions) EnableBackups(ctx context.Context, id int) (*godo.Action, *godo.Response, error) {
+	return m.MockEnableBackups(ctx, id)
+}
+
+func Test_dropletExternal_Update_Backups(t *testing.T) {
+	withBackups := func(want, enabled bool) *v1alpha1.Droplet {
+		cr := droplet(nil)
+		cr.Spec.ForProvider.Backups = &want
+		cr.Status.AtProvider.Backups = enabled
+		cr.Status.AtProvider.Status = v1alpha1.StatusActive
+		return cr
+	}
+
+	type want struct {
+		enabled bool
+		err     error
+	}
+	tests := map[string]struct {
+		cr     *v1alpha1.Droplet
+		enable func(context.Context, int) (*godo.Action, *godo.Response, error)
+		want   want
+	}{
+		"EnableBackups": {
+			cr:     withBackups(true, false),
+			enable: func(context.Context, int) (*godo.Action, *godo.Response, error) { return &godo.Action{}, nil, nil },
+			want:   want{enabled: true},
+		},
+		"EnableBackupsFailed": {
+			cr:     withBackups(true, false),
+			enable: func(context.Context, int) (*godo.Action, *godo.Response, error) { return nil, nil, errors.New("boom") },
+			want:   want{enabled: true, err: errors.Wrap(errors.New("boom"), errEnableBackups)},
+		},
+		"AlreadyEnabled": {
+			cr: withBackups(true, true),
+		},
+		"NeverDisabled": {
+			cr: withBackups(false, true),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			e := &dropletExternal{
+				Client: &godo.Client{
+					Droplets: &mockDroplets{MockGet: func(_ context.Context, id int) (*godo.Droplet, *godo.Response, error) {
+						return &godo.Droplet{ID: id}, &godo.Response{}, nil
+					}},
+					DropletActions: &mockDropletActions{MockEnableBackups: func(ctx context.Context, id int) (*godo.Action, *godo.Response, error) {
+						got.enabled = id == dropletID
+						return tc.enable(ctx, id)
+					}},
+				},
+			}
+			_, got.err = e.Update(context.Background(), tc.cr)
+
+			if diff := cmp.Diff(tc.want.err, got.err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.enabled, got.enabled); diff != "" {
+				t.Errorf("Update(...): -want backups enabled, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_dropletExternal_Update_Tags(t *testing.T) {
+	resources := []godo.Resource{{ID: strconv.Itoa(dropletID), Type: godo.DropletResourceType}}
+
+	type want struct {
+		added   []string
+		removed []string
+		managed []string
+		err     error
+	}
+	tests := map[string]struct {
+		desired  []string
+		managed  []string
+		observed []string
+		want     want
+	}{
+		"Add": {
+			desired:  []string{"a", "b"},
+			observed: []string{"external"},
+			want:     want{added: []string{"a", "b"}, managed: []string{"a", "b"}},
+		},
+		"Remove": {
+			managed:  []string{"a", "b"},
+			observed: []string{"a", "b", "external"},
+			want:     want{removed: []string{"a", "b"}},
+		},
+		"Mixed": {
+			desired:  []string{"a", "c"},
+			managed:  []string{"a", "b"},
+			observed: []string{"a", "b", "external"},
+			want:     want{added: []string{"c"}, removed: []string{"b"}, managed: []string{"a", "c"}},
+		},
+		"InAnotherOrder": {
+			desired:  []string{"b", "a"},
+			managed:  []string{"a", "b"},
+			observed: []string{"external", "b", "a"},
+			want:     want{managed: []string{"a", "b"}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var added, removed []string
+			tags := &dofake.MockResourceTagsClient{
+				MockCreate: func(_ context.Context, req *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error) {
+					return &godo.Tag{Name: req.Name}, &godo.Response{}, nil
+				},
+				MockTagResources: func(_ context.Context, tag string, req *godo.TagResourcesRequest) (*godo.Response, error) {
+					if diff := cmp.Diff(resources, req.Resources); diff != "" {
+						return nil, errors.New(diff)
+					}
+					added = append(added, tag)
+					return &godo.Response{}, nil
+				},
+				MockUntagResources: func(_ context.Context, tag string, req *godo.UntagResourcesRequest) (*godo.Response, error) {
+					if diff := cmp.Diff(resources, req.Resources); diff != "" {
+						return nil, errors.New(diff)
+					}
+					removed = append(removed, tag)
+					return &godo.Response{}, nil
+				},
+			}
+			cr := droplet(nil)
+			cr.Spec.ForProvider.Tags = tc.desired
+			do.SetManagedTags(cr, tc.managed)
+
+			e := &dropletExternal{
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				Client: &godo.Client{Droplets: &mockDroplets{MockGet: func(_ context.Context, id int) (*godo.Droplet, *godo.Response, error) {
+					return &godo.Droplet{ID: id, Tags: tc.observed}, &godo.Response{}, nil
+				}}},
+				tags: tags,
+			}
+			_, err := e.Update(context.Background(), cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.added, added); diff != "" {
+				t.Errorf("TagResources: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.removed, removed); diff != "" {
+				t.Errorf("UntagResources: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.managed, do.GetManagedTags(cr)); diff != "" {
+				t.Errorf("GetManagedTags: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,11 +15,13 @@ package database
 
 import (
 	"context"
-	"strconv"
+	"time"
 
 	"github.com/digitalocean/godo"
-	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -33,52 +35,122 @@ import (
 	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dodb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
 )
 
 const (
 	// Error strings.
 	errNotDB          = "managed resource is not a Database Cluster resource"
 	errGetDB          = "cannot get a Database Cluster"
+	errFindDB         = "cannot find a Database Cluster to adopt by name"
+	errFindCreatedDB  = "cannot find a Database Cluster that was already created"
+	errGetCA          = "cannot get the CA certificate of a Database Cluster"
 	errDBNameRequired = "name of Database Cluster is required"
 
-	errDBCreateFailed = "creation of Database Cluster resource has failed"
-	errDBDeleteFailed = "deletion of Database Cluster resource has failed"
-	errDBUpdate       = "cannot update managed Database Cluster resource"
+	errDBCreateFailed   = "creation of Database Cluster resource has failed"
+	errDBCreateTimedOut = "creation of Database Cluster resource timed out and will be retried"
+	errDBDeleteFailed   = "deletion of Database Cluster resource has failed"
+	errDBUpdate         = "cannot update managed Database Cluster resource"
+
+	errGetMigration         = "cannot get the online migration status of a Database Cluster"
+	errStartMigration       = "cannot start the online migration of a Database Cluster"
+	errCreateInitialDB      = "cannot create the initial database of a Database Cluster"
+	errGetVPC               = "cannot get the VPC of a Database Cluster"
+	errGetFirewall          = "cannot get the trusted sources of a Database Cluster"
+	errUpdateFirewall       = "cannot update the trusted sources of a Database Cluster"
+	errListReplicas         = "cannot list the replicas of a Database Cluster"
+	errCreateUser           = "cannot create a user of a Database Cluster"
+	errDeleteUser           = "cannot delete a user of a Database Cluster"
+	errGetMigrationPassword = "cannot get the password of the online migration source"
+
+	errPausedConfig    = "cannot read the paused config of a Database Cluster"
+	errListPausedSizes = "cannot list the sizes a Database Cluster can be paused at"
+	errDBResize        = "cannot resize Database Cluster"
+	errGetConfig       = "cannot get the config of a Database Cluster"
+	errUpdateConfig    = "cannot update the config of a Database Cluster"
+
+	errGetMetrics     = "cannot get the metrics credentials and endpoints of a Database Cluster"
+	errPublishMetrics = "cannot write the metrics secret of a Database Cluster"
+)
+
+// Event reasons.
+const (
+	reasonForkCompleted event.Reason = "ForkCompleted"
 )
 
 // SetupDatabase adds a controller that reconciles Database managed
 // resources.
-func SetupDatabase(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDatabase(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.DBGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	connector := &dbConnector{
+		kube:                  mgr.GetClient(),
+		record:                recorder,
+		readOnlySpec:          o.ReadOnlySpec,
+		timeout:               o.OperationTimeout,
+		skipAvailabilityCheck: o.SkipAvailabilityChecks,
+		renderObserved:        o.RenderObservedParameters,
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.DODatabaseCluster{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DBGroupVersionKind),
-			managed.WithExternalConnecter(&dbConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(do.NewObserveOnlyConnecter(connector, o.ObserveOnly)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), do.NewDefaultConnectionSecretNamespace(mgr.GetClient(), o.ConnectionSecretNamespace)),
+			managed.WithConnectionPublishers(do.NewChecksumPublisher(managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), mgr.GetClient())),
+			managed.WithFinalizer(resource.NewAPIFinalizer(mgr.GetClient(), o.Finalizer)),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(recorder)))
 }
 
 type dbConnector struct {
-	kube client.Client
+	kube                  client.Client
+	record                event.Recorder
+	readOnlySpec          bool
+	timeout               time.Duration
+	skipAvailabilityCheck bool
+	renderObserved        bool
 }
 
 func (c *dbConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	client, pc, err := do.ConnectWithConfig(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
-	return &dbExternal{Client: client, kube: c.kube}, nil
+	return &dbExternal{
+		kube:                  c.kube,
+		client:                client.Databases,
+		vpcs:                  client.VPCs,
+		migration:             dodb.NewMigrationClient(client),
+		metrics:               dodb.NewMetricsClient(client),
+		record:                c.record,
+		readOnlySpec:          c.readOnlySpec,
+		timeout:               c.timeout,
+		allowedRegions:        pc.AllowedRegions,
+		skipAvailabilityCheck: c.skipAvailabilityCheck,
+		renderObserved:        c.renderObserved,
+	}, nil
 }
 
 type dbExternal struct {
-	kube client.Client
-	*godo.Client
+	kube         client.Client
+	client       dodb.DatabaseClient
+	vpcs         dodb.VPCGetter
+	migration    dodb.MigrationClient
+	metrics      dodb.MetricsClient
+	record       event.Recorder
+	readOnlySpec bool
+	timeout      time.Duration
+
+	// allowedRegions are the regions the ProviderConfig allows clusters to
+	// be created in. All are allowed if it is empty.
+	allowedRegions []string
+
+	skipAvailabilityCheck bool
+	renderObserved        bool
 }
 
 func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -87,25 +159,54 @@ func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		return managed.ExternalObservation{}, errors.New(errNotDB)
 	}
 
+	// A paused cluster is reported as existing and up to date without calling
+	// the DigitalOcean API, so that it is neither created nor updated.
+	if do.IsPaused(cr) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
 	if meta.GetExternalName(cr) == "" {
-		return managed.ExternalObservation{
-			ResourceExists: false,
-		}, nil
+		// Without an external name the cluster is only looked up by name if
+		// the user explicitly opted in to adopting it.
+		if !do.ShouldAdoptByName(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		existing, err := dodb.FindByName(ctx, c.client, cr.GetName())
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errFindDB)
+		}
+		if existing == nil {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		meta.SetExternalName(cr, existing.ID)
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errDBUpdate)
+		}
 	}
 
-	observed, response, err := c.Databases.Get(ctx, meta.GetExternalName(cr))
+	observed, response, err := c.client.Get(ctx, meta.GetExternalName(cr))
 	if err != nil {
+		if do.IsRetryable(response, err) {
+			// The API is temporarily unavailable. Assume nothing changed and
+			// check again at the next poll rather than reporting an error.
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+		if do.IsLocked(response, err) {
+			// The cluster is locked, e.g. by maintenance. Report it as not up
+			// to date so that it's observed and updated again later.
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+		}
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetDB)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
-	dodb.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errDBUpdate)
-		}
+	lateInit := cr.Spec.ForProvider.DeepCopy()
+	dodb.LateInitializeSpec(lateInit, *observed)
+	if err := do.PersistLateInit(ctx, c.kube, cr, &cr.Spec.ForProvider, lateInit, !c.readOnlySpec); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDBUpdate)
 	}
 
+	migration := cr.Status.AtProvider.OnlineMigration
+	previousStatus := cr.Status.AtProvider.Status
 	cr.Status.AtProvider = v1alpha1.DODatabaseClusterObservation{
 		ID:                 &observed.ID,
 		Name:               observed.Name,
@@ -115,7 +216,7 @@ func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		Size:               observed.SizeSlug,
 		Region:             observed.RegionSlug,
 		Status:             observed.Status,
-		CreatedAt:          observed.CreatedAt.String(),
+		CreatedAt:          do.FormatTime(observed.CreatedAt),
 		PrivateNetworkUUID: observed.PrivateNetworkUUID,
 		Tags:               observed.Tags,
 		DbNames:            observed.DBNames,
@@ -160,25 +261,113 @@ func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		}
 	}
 
-	setCrossplaneStatus(cr)
+	cr.Status.AtProvider.OnlineMigration = migration
+	if c.renderObserved {
+		cr.Status.AtProvider.ObservedParameters = dodb.GenerateObservedParameters(*observed)
+	}
+	if fork := cr.Spec.ForProvider.Fork; fork != nil {
+		cr.Status.AtProvider.ForkedFrom = fork.SourceClusterName
+		if (previousStatus == v1alpha1.StatusCreating || previousStatus == v1alpha1.StatusForking) && observed.Status == v1alpha1.StatusOnline {
+			c.record.Event(cr, event.Normal(reasonForkCompleted, "Forked from Database Cluster "+fork.SourceClusterName))
+		}
+	}
+	if cr.Spec.ForProvider.OnlineMigration != nil && observed.Status == v1alpha1.StatusOnline {
+		status, response, err := c.migration.GetOnlineMigrationStatus(ctx, observed.ID)
+		if err != nil && do.IgnoreNotFound(err, response) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetMigration)
+		}
+		if status != nil && status.ID != "" {
+			cr.Status.AtProvider.OnlineMigration = v1alpha1.DODatabaseClusterOnlineMigrationObservation{
+				ID:        status.ID,
+				Status:    status.Status,
+				CreatedAt: status.CreatedAt,
+			}
+		}
+	}
+
+	if cr.Spec.ForProvider.Metrics != nil && observed.Status == v1alpha1.StatusOnline {
+		if err := c.publishMetrics(ctx, cr, observed.ID); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	if observed.Status == v1alpha1.StatusOnline {
+		if err := c.observeTrustedSources(ctx, cr, observed); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	if observed.Status == v1alpha1.StatusOnline && dodb.SupportsReplicas(observed.EngineSlug) {
+		replicas, err := dodb.ListReplicas(ctx, c.client, observed.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListReplicas)
+		}
+		cr.Status.AtProvider.CrossRegionReplica, cr.Status.AtProvider.ReplicaRegions = dodb.GenerateReplicaObservation(observed.RegionSlug, replicas)
+	}
+
+	configUpToDate, err := c.isConfigUpToDate(ctx, cr)
+	if err != nil && !do.IsLocked(nil, err) {
+		return managed.ExternalObservation{}, err
+	}
 
-	return managed.ExternalObservation{
+	do.SetStatusCondition(cr, cr.Status.AtProvider.Status, dbConditions)
+
+	upToDate := configUpToDate && dodb.IsPauseUpToDate(cr) &&
+		!dodb.NeedsOnlineMigration(cr.Spec.ForProvider, cr.Status.AtProvider) &&
+		!dodb.NeedsInitialDatabase(cr.Spec.ForProvider, cr.Status.AtProvider) &&
+		dodb.IsPublicAccessUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider) &&
+		dodb.AreTrustedDropletTagsUpToDate(cr) &&
+		dodb.AreUsersUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider)
+
+	obs := managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: true,
-	}, nil
-}
+		ResourceUpToDate: upToDate,
+	}
 
-func setCrossplaneStatus(cr *v1alpha1.DODatabaseCluster) {
-	switch cr.Status.AtProvider.Status {
-	case v1alpha1.StatusCreating:
-		cr.SetConditions(xpv1.Creating())
-	case v1alpha1.StatusOnline:
-		cr.SetConditions(xpv1.Available())
-	case v1alpha1.StatusMigrating:
-	case v1alpha1.StatusResizing:
-	case v1alpha1.StatusForking:
-		cr.SetConditions(xpv1.Unavailable())
+	// The CA certificate is only needed, and only fetched, when the user asked
+	// for the server certificate to be verified.
+	if cr.Spec.WriteConnectionSecretToReference != nil && dodb.VerifyFull(cr.Spec.ForProvider.Connection) {
+		ca, _, err := c.client.GetCA(ctx, observed.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetCA)
+		}
+		obs.ConnectionDetails = dodb.GenerateConnectionDetails(observed, dodb.ConnectionParameters(cr.Spec.ForProvider), ca.Certificate)
 	}
+
+	// Otherwise the connection details are only published at creation. They
+	// are republished if they changed since, e.g. because DigitalOcean moved
+	// the cluster to a new host after a migration.
+	if cr.Spec.WriteConnectionSecretToReference != nil && obs.ConnectionDetails == nil {
+		cd := dodb.GenerateConnectionDetails(observed, dodb.ConnectionParameters(cr.Spec.ForProvider), nil)
+		changed, err := do.ConnectionDetailsChanged(ctx, c.kube, cr, cd)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if changed {
+			obs.ConnectionDetails = cd
+		}
+	}
+
+	// The passwords of managed users are published on every observation, as
+	// users are created after the cluster.
+	if cr.Spec.WriteConnectionSecretToReference != nil && len(cr.Spec.ForProvider.Users) > 0 {
+		if obs.ConnectionDetails == nil {
+			obs.ConnectionDetails = managed.ConnectionDetails{}
+		}
+		for k, v := range dodb.GenerateUserConnectionDetails(cr.Spec.ForProvider.Users, cr.Status.AtProvider.Users) {
+			obs.ConnectionDetails[k] = v
+		}
+	}
+
+	return obs, nil
+}
+
+// dbConditions maps the statuses of a Database Cluster to the conditions they
+// result in.
+var dbConditions = do.StatusConditions{
+	v1alpha1.StatusCreating: xpv1.Creating,
+	v1alpha1.StatusOnline:   xpv1.Available,
+	v1alpha1.StatusForking:  xpv1.Unavailable,
 }
 
 func (c *dbExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -202,43 +391,403 @@ func (c *dbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 		return managed.ExternalCreation{}, errors.New(errDBNameRequired)
 	}
 
+	if err := dodb.ValidateConfig(cr.Spec.ForProvider, do.StringValue(cr.Spec.ForProvider.Engine)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
+	}
+
+	if err := dodb.ValidateInitialDatabaseName(cr.Spec.ForProvider, do.StringValue(cr.Spec.ForProvider.Engine)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
+	}
+
+	if err := dodb.ValidateConnectionTemplates(cr.Spec.ForProvider.Connection); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
+	}
+
+	if err := do.ValidateTags(cr.Spec.ForProvider.Tags); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
+	}
+
+	if err := do.ValidateRegion(cr.Spec.ForProvider.Region, c.allowedRegions); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
+	}
+
+	if !c.skipAvailabilityCheck {
+		if err := dodb.ValidateAvailability(ctx, c.client, cr.Spec.ForProvider); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
+		}
+	}
+
+	ctx, cancel := do.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// A previous Create may have created the cluster without its external
+	// name being persisted, e.g. because the provider crashed or the request
+	// timed out. Cluster names are unique, so such a cluster is adopted
+	// rather than attempted to be created again.
+	existing, err := dodb.FindByName(ctx, c.client, name)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFindCreatedDB)
+	}
+	if existing != nil && dodb.WasCreatedFor(*existing, cr.Spec.ForProvider, cr.GetCreationTimestamp().Time) {
+		meta.SetExternalName(cr, existing.ID)
+		return creation(cr, existing), nil
+	}
+
 	dodb.GenerateDatabase(name, cr.Spec.ForProvider, create)
 
-	db, _, err := c.Databases.Create(ctx, create)
+	db, _, err := c.client.Create(ctx, create)
+	if do.IsTimedOut(err) {
+		// The managed reconciler requeues failed creations, so the cluster is
+		// adopted, or created again, at a later reconcile.
+		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateTimedOut)
+	}
 	if err != nil || db == nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
 	}
 
 	meta.SetExternalName(cr, db.ID)
 
+	return creation(cr, db), nil
+}
+
+// creation returns the result of creating the supplied cluster.
+func creation(cr *v1alpha1.DODatabaseCluster, db *godo.Database) managed.ExternalCreation {
 	ec := managed.ExternalCreation{}
 
 	if cr.Spec.WriteConnectionSecretToReference != nil {
-		ec.ConnectionDetails = managed.ConnectionDetails{
-			xpv1.ResourceCredentialsSecretEndpointKey: []byte(db.Connection.URI),
-			"host":                                    []byte(db.Connection.Host),
-			xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(db.Connection.Port)),
-			xpv1.ResourceCredentialsSecretUserKey:     []byte(db.Connection.User),
-			xpv1.ResourceCredentialsSecretPasswordKey: []byte(db.Connection.Password),
-		}
+		ec.ConnectionDetails = dodb.GenerateConnectionDetails(db, dodb.ConnectionParameters(cr.Spec.ForProvider), nil)
 	}
 
-	return ec, nil
+	return ec
 }
 
 func (c *dbExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// We don't allow the updating of databases right now.
+	cr, ok := mg.(*v1alpha1.DODatabaseCluster)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDB)
+	}
+
+	ctx, cancel := do.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// A locked cluster, or one that took too long to update, is left as is and
+	// updated at the next poll.
+	if err := c.update(ctx, cr); err != nil && !do.IsLocked(nil, err) && !do.IsTimedOut(err) {
+		return managed.ExternalUpdate{}, err
+	}
 	return managed.ExternalUpdate{}, nil
 }
 
+func (c *dbExternal) update(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	// The cluster itself can't be updated right now, only paused, resumed,
+	// configured, have its trusted sources updated, have its initial database
+	// and users created or have an online migration started.
+	if !dodb.IsPauseUpToDate(cr) {
+		return c.updatePause(ctx, cr)
+	}
+	// Updating the public access also applies the desired Droplet tags.
+	switch {
+	case !dodb.IsPublicAccessUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider):
+		if err := c.updatePublicAccess(ctx, cr); err != nil {
+			return err
+		}
+	case !dodb.AreTrustedDropletTagsUpToDate(cr):
+		if err := c.updateTrustedDropletTags(ctx, cr); err != nil {
+			return err
+		}
+	}
+	if dodb.NeedsInitialDatabase(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		if err := c.createInitialDatabase(ctx, cr); err != nil {
+			return err
+		}
+	}
+	if !dodb.AreUsersUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		if err := c.updateUsers(ctx, cr); err != nil {
+			return err
+		}
+	}
+	if dodb.NeedsOnlineMigration(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		if err := c.startOnlineMigration(ctx, cr); err != nil {
+			return err
+		}
+	}
+	return c.updateConfig(ctx, cr)
+}
+
+// isConfigUpToDate returns true if the advanced config specified for the
+// cluster matches its observed config. Config is only observed once the
+// cluster is online.
+func (c *dbExternal) isConfigUpToDate(ctx context.Context, cr *v1alpha1.DODatabaseCluster) (bool, error) {
+	o := cr.Status.AtProvider
+	if o.Status != v1alpha1.StatusOnline {
+		return true, nil
+	}
+	if err := dodb.ValidateConfig(cr.Spec.ForProvider, o.Engine); err != nil {
+		return false, err
+	}
+	switch {
+	case dodb.HasPostgreSQLConfig(cr.Spec.ForProvider) && o.Engine == v1alpha1.EnginePostgreSQL:
+		observed, _, err := c.client.GetPostgreSQLConfig(ctx, meta.GetExternalName(cr))
+		if err != nil {
+			return false, errors.Wrap(err, errGetConfig)
+		}
+		cr.Status.AtProvider.Timezone = do.StringValue(observed.Timezone)
+		return dodb.IsPostgreSQLConfigUpToDate(*cr.Spec.ForProvider.Config.PostgreSQL, *observed), nil
+	case dodb.HasRedisConfig(cr.Spec.ForProvider) && o.Engine == v1alpha1.EngineRedis:
+		observed, _, err := c.client.GetRedisConfig(ctx, meta.GetExternalName(cr))
+		if err != nil {
+			return false, errors.Wrap(err, errGetConfig)
+		}
+		return dodb.IsRedisConfigUpToDate(*cr.Spec.ForProvider.Config.Redis, *observed), nil
+	}
+	return true, nil
+}
+
+// updateConfig updates the advanced config of the cluster if it has drifted.
+// Only the settings that are specified are sent, so all others are left as
+// they are.
+func (c *dbExternal) updateConfig(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	upToDate, err := c.isConfigUpToDate(ctx, cr)
+	if err != nil || upToDate {
+		return err
+	}
+	switch cr.Status.AtProvider.Engine {
+	case v1alpha1.EnginePostgreSQL:
+		_, err = c.client.UpdatePostgreSQLConfig(ctx, meta.GetExternalName(cr), dodb.GeneratePostgreSQLConfig(*cr.Spec.ForProvider.Config.PostgreSQL))
+	case v1alpha1.EngineRedis:
+		_, err = c.client.UpdateRedisConfig(ctx, meta.GetExternalName(cr), dodb.GenerateRedisConfig(*cr.Spec.ForProvider.Config.Redis))
+	}
+	return errors.Wrap(err, errUpdateConfig)
+}
+
+// updatePause resizes the cluster down to its paused size, or back up to the
+// size it had before it was paused. A cluster that has no paused size is left
+// as is, and its Paused condition says why.
+func (c *dbExternal) updatePause(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	pc, err := dodb.GetPausedConfig(cr)
+	if err != nil {
+		return errors.Wrap(err, errPausedConfig)
+	}
+
+	if !do.BoolValue(cr.Spec.ForProvider.Paused) {
+		if _, err := c.client.Resize(ctx, meta.GetExternalName(cr), &godo.DatabaseResizeRequest{SizeSlug: pc.Size, NumNodes: pc.NumNodes}); err != nil {
+			return errors.Wrap(err, errDBResize)
+		}
+		meta.RemoveAnnotations(cr, dodb.AnnotationPausedConfig)
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return errors.Wrap(err, errDBUpdate)
+		}
+		cr.SetConditions(dodb.Resumed())
+		return nil
+	}
+
+	options, _, err := c.client.ListOptions(ctx)
+	if err != nil {
+		return errors.Wrap(err, errListPausedSizes)
+	}
+	size, ok := dodb.PausedSize(options, cr.Status.AtProvider)
+	if !ok {
+		cr.SetConditions(dodb.CannotPause(cr.Status.AtProvider))
+		return nil
+	}
+
+	// The prior config is persisted before resizing so that it can't be lost
+	// if the resize succeeds but we fail to record it afterwards.
+	if pc == nil {
+		if err := dodb.SetPausedConfig(cr, dodb.PausedConfig{Size: cr.Status.AtProvider.Size, NumNodes: cr.Status.AtProvider.NumNodes}); err != nil {
+			return errors.Wrap(err, errPausedConfig)
+		}
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return errors.Wrap(err, errDBUpdate)
+		}
+	}
+	if cr.Status.AtProvider.Size != size || cr.Status.AtProvider.NumNodes != dodb.PausedNumNodes {
+		if _, err := c.client.Resize(ctx, meta.GetExternalName(cr), &godo.DatabaseResizeRequest{SizeSlug: size, NumNodes: dodb.PausedNumNodes}); err != nil {
+			return errors.Wrap(err, errDBResize)
+		}
+	}
+	cr.SetConditions(dodb.Paused())
+	return nil
+}
+
+func (c *dbExternal) startOnlineMigration(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	ref := cr.Spec.ForProvider.OnlineMigration.Source.PasswordSecretRef
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return errors.Wrap(err, errGetMigrationPassword)
+	}
+
+	req := dodb.GenerateOnlineMigration(*cr.Spec.ForProvider.OnlineMigration, string(s.Data[ref.Key]))
+	status, _, err := c.migration.StartOnlineMigration(ctx, meta.GetExternalName(cr), req)
+	if err != nil {
+		return errors.Wrap(err, errStartMigration)
+	}
+
+	cr.Status.AtProvider.OnlineMigration = v1alpha1.DODatabaseClusterOnlineMigrationObservation{
+		ID:        status.ID,
+		Status:    status.Status,
+		CreatedAt: status.CreatedAt,
+	}
+	return nil
+}
+
+// observeTrustedSources observes whether the trusted sources of the supplied
+// cluster restrict it to its VPC, and which Droplet tags they trust, if
+// either is desired.
+func (c *dbExternal) observeTrustedSources(ctx context.Context, cr *v1alpha1.DODatabaseCluster, observed *godo.Database) error {
+	p := cr.Spec.ForProvider
+	if !dodb.ManagesTrustedDropletTags(cr) {
+		cr.Status.AtProvider.TrustedDropletTags = nil
+	}
+	if p.PublicAccess == nil && !dodb.ManagesTrustedDropletTags(cr) {
+		return nil
+	}
+	rules, _, err := c.client.GetFirewallRules(ctx, observed.ID)
+	if err != nil {
+		return errors.Wrap(err, errGetFirewall)
+	}
+	if p.PublicAccess != nil {
+		ipRange, err := c.vpcIPRange(ctx, observed.PrivateNetworkUUID)
+		if err != nil {
+			return err
+		}
+		public := !dodb.HasVPCRule(rules, ipRange)
+		cr.Status.AtProvider.PublicAccess = &public
+	}
+	if dodb.ManagesTrustedDropletTags(cr) {
+		cr.Status.AtProvider.TrustedDropletTags = dodb.TrustedDropletTags(rules)
+	}
+	return nil
+}
+
+// updatePublicAccess adds the trusted source for the VPC of the cluster, or
+// removes it to lift that restriction, keeping all other trusted sources.
+func (c *dbExternal) updatePublicAccess(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	public := *cr.Spec.ForProvider.PublicAccess
+	ipRange, err := c.vpcIPRange(ctx, cr.Status.AtProvider.PrivateNetworkUUID)
+	if err != nil {
+		return err
+	}
+	observed, _, err := c.client.GetFirewallRules(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return errors.Wrap(err, errGetFirewall)
+	}
+	rules := dodb.WithVPCRule(observed, ipRange, !public)
+	if _, err := c.client.UpdateFirewallRules(ctx, meta.GetExternalName(cr), &godo.DatabaseUpdateFirewallRulesRequest{Rules: rules}); err != nil {
+		return errors.Wrap(err, errUpdateFirewall)
+	}
+	cr.Status.AtProvider.PublicAccess = &public
+	return nil
+}
+
+// updateTrustedDropletTags replaces the trusted sources of type tag of the
+// cluster with the ones for the desired Droplet tags, keeping all others, then
+// records the desired tags as applied.
+func (c *dbExternal) updateTrustedDropletTags(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	observed, _, err := c.client.GetFirewallRules(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return errors.Wrap(err, errGetFirewall)
+	}
+	rules := dodb.WithTagRules(observed, cr.Spec.ForProvider.TrustedDropletTags)
+	if _, err := c.client.UpdateFirewallRules(ctx, meta.GetExternalName(cr), &godo.DatabaseUpdateFirewallRulesRequest{Rules: rules}); err != nil {
+		return errors.Wrap(err, errUpdateFirewall)
+	}
+	dodb.SetManagedTrustedDropletTags(cr, cr.Spec.ForProvider.TrustedDropletTags)
+	return errors.Wrap(c.kube.Update(ctx, cr), errDBUpdate)
+}
+
+func (c *dbExternal) vpcIPRange(ctx context.Context, id string) (string, error) {
+	vpc, _, err := c.vpcs.Get(ctx, id)
+	if err != nil {
+		return "", errors.Wrap(err, errGetVPC)
+	}
+	return vpc.IPRange, nil
+}
+
+// createInitialDatabase creates the initial database of the cluster and
+// records it as observed, so that it is only created once.
+func (c *dbExternal) createInitialDatabase(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	name := *cr.Spec.ForProvider.InitialDatabaseName
+	if err := dodb.ValidateInitialDatabaseName(cr.Spec.ForProvider, cr.Status.AtProvider.Engine); err != nil {
+		return errors.Wrap(err, errCreateInitialDB)
+	}
+	if _, _, err := c.client.CreateDB(ctx, meta.GetExternalName(cr), &godo.DatabaseCreateDBRequest{Name: name}); err != nil {
+		return errors.Wrap(err, errCreateInitialDB)
+	}
+	cr.Status.AtProvider.DbNames = append(cr.Status.AtProvider.DbNames, name)
+	return nil
+}
+
+// updateUsers creates the desired users that don't exist and deletes the
+// ones that are no longer desired. Created users are observed, along with
+// their passwords, at the next poll.
+func (c *dbExternal) updateUsers(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	create, remove := dodb.DiffUsers(cr.Spec.ForProvider.Users, cr.Status.AtProvider.Users)
+	for _, req := range create {
+		if _, _, err := c.client.CreateUser(ctx, meta.GetExternalName(cr), req); err != nil {
+			return errors.Wrap(err, errCreateUser)
+		}
+	}
+	for _, name := range remove {
+		response, err := c.client.DeleteUser(ctx, meta.GetExternalName(cr), name)
+		if do.IgnoreNotFound(err, response) != nil {
+			return errors.Wrap(err, errDeleteUser)
+		}
+	}
+	return nil
+}
+
+// publishMetrics writes the metrics credentials and endpoints of the cluster
+// to its metrics secret. Values that aren't available are left out.
+func (c *dbExternal) publishMetrics(ctx context.Context, cr *v1alpha1.DODatabaseCluster, id string) error {
+	creds, response, err := c.metrics.GetMetricsCredentials(ctx)
+	if err != nil && do.IgnoreNotFound(err, response) != nil {
+		return errors.Wrap(err, errGetMetrics)
+	}
+	endpoints, response, err := c.metrics.GetMetricsEndpoints(ctx, id)
+	if err != nil && do.IgnoreNotFound(err, response) != nil {
+		return errors.Wrap(err, errGetMetrics)
+	}
+
+	ref := cr.Spec.ForProvider.Metrics.WriteSecretToReference
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ref.Namespace,
+			Name:            ref.Name,
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(cr, v1alpha1.DBGroupVersionKind))},
+		},
+		Type: resource.SecretTypeConnection,
+		Data: dodb.GenerateMetricsDetails(creds, endpoints),
+	}
+	err = resource.NewAPIPatchingApplicator(c.kube).Apply(ctx, s, resource.ConnectionSecretMustBeControllableBy(cr.GetUID()))
+	return errors.Wrap(err, errPublishMetrics)
+}
+
 func (c *dbExternal) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.DODatabaseCluster)
 	if !ok {
 		return errors.New(errNotDB)
 	}
 
+	// A paused cluster is not deleted. It is still observed to exist, so the
+	// finalizer is kept and the cluster is deleted once reconciliation is
+	// resumed.
+	if do.IsPaused(cr) {
+		cr.SetConditions(do.DeletionPaused())
+		return nil
+	}
+
 	cr.Status.SetConditions(xpv1.Deleting())
 
-	response, err := c.Databases.Delete(ctx, *cr.Status.AtProvider.ID)
+	id := meta.GetExternalName(cr)
+	if cr.Status.AtProvider.ID != nil && *cr.Status.AtProvider.ID != "" {
+		id = *cr.Status.AtProvider.ID
+	}
+	// Nothing was ever created, so there is nothing to delete.
+	if id == "" {
+		return nil
+	}
+
+	response, err := c.client.Delete(ctx, id)
 	return errors.Wrap(do.IgnoreNotFound(err, response), errDBDeleteFailed)
 }
@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	dodb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database/fake"
+)
+
+// Test_dbExternal_Lifecycle runs a Database Cluster through its lifecycle
+// against a fake DigitalOcean API, the way the managed reconciler would.
+func Test_dbExternal_Lifecycle(t *testing.T) {
+	srv := fake.NewDatabaseServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	e := &dbExternal{
+		kube:                  &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		client:                c.Databases,
+		vpcs:                  c.VPCs,
+		migration:             dodb.NewMigrationClient(c),
+		metrics:               dodb.NewMetricsClient(c),
+		record:                event.NewNopRecorder(),
+		skipAvailabilityCheck: true,
+	}
+	ctx := context.Background()
+	cr := database(withSpec(v1alpha1.DODatabaseClusterParameters{
+		Engine:              godo.String(v1alpha1.EnginePostgreSQL),
+		NumNodes:            1,
+		Size:                "db-s-1vcpu-1gb",
+		Region:              "nyc1",
+		InitialDatabaseName: godo.String("app"),
+	}))
+
+	observe := func(want managed.ExternalObservation) {
+		t.Helper()
+		got, err := e.Observe(ctx, cr)
+		if err != nil {
+			t.Fatalf("Observe(...): %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Observe(...): -want, +got:\n%s", diff)
+		}
+	}
+
+	observe(managed.ExternalObservation{ResourceExists: false})
+
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	id := meta.GetExternalName(cr)
+	if srv.Database(id) == nil {
+		t.Fatalf("Create(...): cluster %q was not created", id)
+	}
+
+	observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true})
+	if diff := cmp.Diff(xpv1.Creating(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("Observe(...): -want condition, +got condition:\n%s", diff)
+	}
+
+	// The initial database is only created once the cluster is online.
+	srv.SetStatus(id, v1alpha1.StatusOnline)
+	observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false})
+	if diff := cmp.Diff(xpv1.Available(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("Observe(...): -want condition, +got condition:\n%s", diff)
+	}
+
+	if _, err := e.Update(ctx, cr); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"defaultdb", "app"}, srv.Database(id).DBNames); diff != "" {
+		t.Errorf("Update(...): -want databases, +got databases:\n%s", diff)
+	}
+
+	observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true})
+
+	if err := e.Delete(ctx, cr); err != nil {
+		t.Fatalf("Delete(...): %v", err)
+	}
+	if srv.Database(id) != nil {
+		t.Errorf("Delete(...): cluster %q was not deleted", id)
+	}
+
+	// Deleting a cluster that is already gone succeeds.
+	if err := e.Delete(ctx, cr); err != nil {
+		t.Errorf("Delete(...): %v", err)
+	}
+	observe(managed.ExternalObservation{ResourceExists: false})
+}
+
+// Test_dbExternal_CreateAfterCrash simulates the provider crashing after it
+// created a Database Cluster but before the external name was persisted.
+func Test_dbExternal_CreateAfterCrash(t *testing.T) {
+	srv := fake.NewDatabaseServer()
+	defer srv.Close()
+
+	e := &dbExternal{client: srv.Client().Databases, skipAvailabilityCheck: true}
+	ctx := context.Background()
+	params := v1alpha1.DODatabaseClusterParameters{
+		Engine:   godo.String(v1alpha1.EnginePostgreSQL),
+		NumNodes: 1,
+		Size:     "db-s-1vcpu-1gb",
+		Region:   "nyc1",
+	}
+
+	// The external name set by the first Create is lost along with the
+	// in-memory object.
+	if _, err := e.Create(ctx, database(withSpec(params))); err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	cr := database(withSpec(params))
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+
+	if diff := cmp.Diff(1, countRequests(srv.Requests(), "POST /v2/databases")); diff != "" {
+		t.Errorf("Create(...): -want clusters created, +got:\n%s", diff)
+	}
+	if srv.Database(meta.GetExternalName(cr)) == nil {
+		t.Errorf("Create(...): external name %q is not the created cluster", meta.GetExternalName(cr))
+	}
+
+	// A cluster that existed before the managed resource is not adopted, so
+	// creating one with the same name fails.
+	cr = database(withSpec(params))
+	cr.SetCreationTimestamp(metav1.NewTime(time.Now().Add(time.Hour)))
+	if _, err := e.Create(ctx, cr); err == nil {
+		t.Errorf("Create(...): want error, got nil")
+	}
+	if meta.GetExternalName(cr) != "" {
+		t.Errorf("Create(...): want no external name, got %q", meta.GetExternalName(cr))
+	}
+}
+
+func countRequests(requests []string, request string) int {
+	n := 0
+	for _, r := range requests {
+		if r == request {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dodb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
+)
+
+const (
+	// Error strings.
+	errNotLogsink = "managed resource is not a Database Logsink resource"
+	errGetLogsink = "cannot list the logsinks of a Database Cluster"
+
+	errLogsinkCreateFailed = "creation of Database Logsink resource has failed"
+	errLogsinkUpdateFailed = "update of Database Logsink resource has failed"
+	errLogsinkDeleteFailed = "deletion of Database Logsink resource has failed"
+
+	errGetLogsinkSecret = "cannot get a secret referenced by a Database Logsink"
+	errLogsinkNoCluster = "cluster ID of Database Logsink is required"
+)
+
+// SetupLogsink adds a controller that reconciles Database Logsink managed
+// resources.
+func SetupLogsink(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.DODatabaseLogsinkGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.DODatabaseLogsink{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DODatabaseLogsinkGroupVersionKind),
+			managed.WithExternalConnecter(do.NewObserveOnlyConnecter(&logsinkConnector{kube: mgr.GetClient()}, o.ObserveOnly)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient())),
+			managed.WithFinalizer(resource.NewAPIFinalizer(mgr.GetClient(), o.Finalizer)),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type logsinkConnector struct {
+	kube client.Client
+}
+
+func (c *logsinkConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	client, err := do.Connect(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &logsinkExternal{client: dodb.NewLogsinkClient(client), kube: c.kube}, nil
+}
+
+type logsinkExternal struct {
+	kube   client.Client
+	client dodb.LogsinkClient
+}
+
+func (c *logsinkExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DODatabaseLogsink)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLogsink)
+	}
+
+	clusterID := do.StringValue(cr.Spec.ForProvider.ClusterID)
+	if clusterID == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	sinks, response, err := c.client.ListLogsinks(ctx, clusterID)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetLogsink)
+	}
+
+	// Logsinks are matched by name, as their ID isn't known until they've
+	// been created.
+	observed := dodb.FindLogsink(sinks, meta.GetExternalName(cr))
+	if observed == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider.ID = observed.ID
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: dodb.IsLogsinkUpToDate(cr.Spec.ForProvider, *observed),
+	}, nil
+}
+
+func (c *logsinkExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DODatabaseLogsink)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLogsink)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	clusterID := do.StringValue(cr.Spec.ForProvider.ClusterID)
+	if clusterID == "" {
+		return managed.ExternalCreation{}, errors.New(errLogsinkNoCluster)
+	}
+	if err := dodb.ValidateLogsink(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errLogsinkCreateFailed)
+	}
+	secrets, err := c.getSecrets(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	create := &dodb.Logsink{
+		Name:   meta.GetExternalName(cr),
+		Type:   cr.Spec.ForProvider.Type,
+		Config: dodb.GenerateLogsinkConfig(cr.Spec.ForProvider, secrets),
+	}
+	sink, _, err := c.client.CreateLogsink(ctx, clusterID, create)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errLogsinkCreateFailed)
+	}
+	if sink != nil {
+		cr.Status.AtProvider.ID = sink.ID
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *logsinkExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DODatabaseLogsink)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLogsink)
+	}
+
+	if err := dodb.ValidateLogsink(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errLogsinkUpdateFailed)
+	}
+	// The whole configuration is replaced, so the secret values have to be
+	// sent again.
+	secrets, err := c.getSecrets(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	update := &dodb.LogsinkUpdateRequest{Config: dodb.GenerateLogsinkConfig(cr.Spec.ForProvider, secrets)}
+	_, err = c.client.UpdateLogsink(ctx, do.StringValue(cr.Spec.ForProvider.ClusterID), cr.Status.AtProvider.ID, update)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errLogsinkUpdateFailed)
+}
+
+func (c *logsinkExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DODatabaseLogsink)
+	if !ok {
+		return errors.New(errNotLogsink)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// Nothing was observed, so there is nothing to delete.
+	if cr.Status.AtProvider.ID == "" {
+		return nil
+	}
+
+	response, err := c.client.DeleteLogsink(ctx, do.StringValue(cr.Spec.ForProvider.ClusterID), cr.Status.AtProvider.ID)
+	return errors.Wrap(do.IgnoreNotFound(err, response), errLogsinkDeleteFailed)
+}
+
+func (c *logsinkExternal) getSecrets(ctx context.Context, p v1alpha1.DODatabaseLogsinkParameters) (dodb.LogsinkSecrets, error) {
+	s := dodb.LogsinkSecrets{}
+	if p.Rsyslog != nil && p.Rsyslog.KeySecretRef != nil {
+		v, err := c.getSecretValue(ctx, *p.Rsyslog.KeySecretRef)
+		if err != nil {
+			return s, err
+		}
+		s.Key = v
+	}
+	if p.Elasticsearch != nil {
+		v, err := c.getSecretValue(ctx, p.Elasticsearch.URLSecretRef)
+		if err != nil {
+			return s, err
+		}
+		s.URL = v
+	}
+	return s, nil
+}
+
+func (c *logsinkExternal) getSecretValue(ctx context.Context, ref xpv1.SecretKeySelector) (string, error) {
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return "", errors.Wrap(err, errGetLogsinkSecret)
+	}
+	return string(s.Data[ref.Key]), nil
+}
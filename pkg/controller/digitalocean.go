@@ -25,20 +25,26 @@ import (
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/database"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/kubernetes"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/loadbalancer"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/spaces"
 )
 
-// Setup creates all DigitalOcean controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
-		config.Setup,
+// Setup creates all DigitalOcean controllers with the supplied logger and
+// options and adds them to the supplied manager.
+func Setup(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
+	if err := config.Setup(mgr, l); err != nil {
+		return err
+	}
+	for _, setup := range []func(ctrl.Manager, logging.Logger, options.Options) error{
 		compute.SetupDroplet,
 		database.SetupDatabase,
+		database.SetupLogsink,
 		kubernetes.SetupKubernetesCluster,
 		kubernetes.SetupDOContainerRegistry,
 		loadbalancer.SetupLB,
+		spaces.SetupSpacesKey,
 	} {
-		if err := setup(mgr, l); err != nil {
+		if err := setup(mgr, l, o); err != nil {
 			return err
 		}
 	}
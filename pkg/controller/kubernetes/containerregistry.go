@@ -23,7 +23,6 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/digitalocean/godo"
-	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +30,7 @@ import (
 	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
 )
 
 const (
@@ -48,7 +48,7 @@ const (
 
 // SetupDOContainerRegistry adds a controller that reconciles DOContainerRegistry managed
 // resources.
-func SetupDOContainerRegistry(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDOContainerRegistry(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.DOContainerRegistryGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -56,30 +56,32 @@ func SetupDOContainerRegistry(mgr ctrl.Manager, l logging.Logger) error {
 		For(&v1alpha1.DOContainerRegistry{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DOContainerRegistryGroupVersionKind),
-			managed.WithExternalConnecter(&containerRegistryConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(do.NewObserveOnlyConnecter(&containerRegistryConnector{kube: mgr.GetClient(), readOnlySpec: o.ReadOnlySpec}, o.ObserveOnly)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithFinalizer(resource.NewAPIFinalizer(mgr.GetClient(), o.Finalizer)),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
 type containerRegistryConnector struct {
-	kube client.Client
+	kube         client.Client
+	readOnlySpec bool
 }
 
 func (c *containerRegistryConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	client, err := do.Connect(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
-	return &containerRegistryExternal{client: client.Registry, kube: c.kube}, nil
+	return &containerRegistryExternal{client: client.Registry, kube: c.kube, readOnlySpec: c.readOnlySpec}, nil
 }
 
 type containerRegistryExternal struct {
-	kube   client.Client
-	client dok8s.RegistryClient
+	kube         client.Client
+	client       dok8s.RegistryClient
+	readOnlySpec bool
 }
 
 func (c *containerRegistryExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -96,6 +98,9 @@ func (c *containerRegistryExternal) Observe(ctx context.Context, mg resource.Man
 
 	observed, response, err := c.client.Get(ctx)
 	if err != nil {
+		if do.IsRetryable(response, err) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
 		cr.Status.SetConditions(xpv1.Unavailable())
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetContainerRegistry)
 	}
@@ -107,12 +112,10 @@ func (c *containerRegistryExternal) Observe(ctx context.Context, mg resource.Man
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetContainerRegistrySubscription)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
-	dok8s.RegistryLateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errContainerRegistryUpdate)
-		}
+	lateInit := cr.Spec.ForProvider.DeepCopy()
+	dok8s.RegistryLateInitializeSpec(lateInit, *observed)
+	if err := do.PersistLateInit(ctx, c.kube, cr, &cr.Spec.ForProvider, lateInit, !c.readOnlySpec); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errContainerRegistryUpdate)
 	}
 
 	cr.Status.AtProvider = dok8s.GenerateContainerRegistryObservation(observed, subscription)
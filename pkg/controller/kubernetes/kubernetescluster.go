@@ -15,9 +15,9 @@ package kubernetes
 
 import (
 	"context"
+	"time"
 
 	"github.com/digitalocean/godo"
-	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +32,7 @@ import (
 	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
 )
 
 const (
@@ -40,15 +41,20 @@ const (
 	errGetK8s          = "cannot get a DOKubernetesCluster"
 	errK8sNameRequired = "name of DOKubernetesCluster is required"
 
-	errK8sCreateFailed = "creation of DOKubernetesCluster resource has failed"
-	errK8sDeleteFailed = "deletion of DOKubernetesCluster resource has failed"
-	errK8sUpdate       = "cannot update managed DOKubernetesCluster resource"
-	errFetchingConfig  = "fetching of DOKubernetesCluster Kubeconfig has failed"
+	errK8sCreateFailed   = "creation of DOKubernetesCluster resource has failed"
+	errFindCreatedK8s    = "cannot find a DOKubernetesCluster that was already created"
+	errK8sCreateTimedOut = "creation of DOKubernetesCluster resource timed out and will be retried"
+	errK8sDeleteFailed   = "deletion of DOKubernetesCluster resource has failed"
+	errK8sUpdate         = "cannot update managed DOKubernetesCluster resource"
+	errFetchingConfig    = "fetching of DOKubernetesCluster Kubeconfig has failed"
+	errK8sRegistry       = "cannot update the container registry integration of DOKubernetesCluster"
+	errK8sNodePool       = "cannot update a node pool of DOKubernetesCluster"
+	errK8sRecycleNode    = "cannot recycle a node of DOKubernetesCluster"
 )
 
 // SetupKubernetesCluster adds a controller that reconciles DOKubernetesCluster managed
 // resources.
-func SetupKubernetesCluster(mgr ctrl.Manager, l logging.Logger) error {
+func SetupKubernetesCluster(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.DOKubernetesClusterKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -56,29 +62,38 @@ func SetupKubernetesCluster(mgr ctrl.Manager, l logging.Logger) error {
 		For(&v1alpha1.DOKubernetesCluster{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DOKubernetesClusterGroupVersionKind),
-			managed.WithExternalConnecter(&k8sConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(do.NewObserveOnlyConnecter(&k8sConnector{kube: mgr.GetClient(), readOnlySpec: o.ReadOnlySpec, timeout: o.OperationTimeout}, o.ObserveOnly)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithConnectionPublishers(do.NewChecksumPublisher(managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), do.NewDefaultConnectionSecretNamespace(mgr.GetClient(), o.ConnectionSecretNamespace)),
+			managed.WithFinalizer(resource.NewAPIFinalizer(mgr.GetClient(), o.Finalizer)),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
 type k8sConnector struct {
-	kube client.Client
+	kube         client.Client
+	readOnlySpec bool
+	timeout      time.Duration
 }
 
 func (c *k8sConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	client, pc, err := do.ConnectWithConfig(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
-	return &k8sExternal{Client: client, kube: c.kube}, nil
+	return &k8sExternal{client: client.Kubernetes, allowedRegions: pc.AllowedRegions, kube: c.kube, readOnlySpec: c.readOnlySpec, timeout: c.timeout}, nil
 }
 
 type k8sExternal struct {
-	kube client.Client
-	*godo.Client
+	kube         client.Client
+	client       dok8s.KubernetesClient
+	readOnlySpec bool
+	timeout      time.Duration
+
+	// allowedRegions are the regions the ProviderConfig allows clusters to
+	// be created in. All are allowed if it is empty.
+	allowedRegions []string
 }
 
 func (c *k8sExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -93,29 +108,32 @@ func (c *k8sExternal) Observe(ctx context.Context, mg resource.Managed) (managed
 		}, nil
 	}
 
-	observed, response, err := c.Kubernetes.Get(ctx, meta.GetExternalName(cr))
+	observed, response, err := c.client.Get(ctx, meta.GetExternalName(cr))
 	if err != nil {
+		if do.IsRetryable(response, err) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetK8s)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
-	dok8s.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errK8sUpdate)
-		}
+	lateInit := cr.Spec.ForProvider.DeepCopy()
+	dok8s.LateInitializeSpec(lateInit, *observed)
+	if err := do.PersistLateInit(ctx, c.kube, cr, &cr.Spec.ForProvider, lateInit, !c.readOnlySpec); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errK8sUpdate)
 	}
 
+	recycling := cr.Status.AtProvider.RecyclingNodes
 	cr.Status.AtProvider = dok8s.GenerateObservation(observed)
+	cr.Status.AtProvider.RecyclingNodes = dok8s.ObservedNodes(cr.Status.AtProvider, recycling)
 	dok8s.SetCondition(cr)
 
 	extObs := managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceUpToDate: dok8s.IsUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider) && len(dok8s.NodesToRecycle(cr)) == 0,
 	}
 
 	if cr.Spec.WriteConnectionSecretToReference != nil {
-		config, resp, err := c.Kubernetes.GetKubeConfig(ctx, observed.ID)
+		config, resp, err := c.client.GetKubeConfig(ctx, observed.ID)
 
 		if err != nil || resp.StatusCode >= 300 {
 			return managed.ExternalObservation{}, errors.Wrap(err, errFetchingConfig)
@@ -149,9 +167,38 @@ func (c *k8sExternal) Create(ctx context.Context, mg resource.Managed) (managed.
 		return managed.ExternalCreation{}, errors.New(errK8sNameRequired)
 	}
 
+	if err := dok8s.ValidateTags(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errK8sCreateFailed)
+	}
+
+	if err := do.ValidateRegion(cr.Spec.ForProvider.Region, c.allowedRegions); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errK8sCreateFailed)
+	}
+
 	dok8s.GenerateKubernetes(name, cr.Spec.ForProvider, create)
 
-	k8s, _, err := c.Kubernetes.Create(ctx, create)
+	ctx, cancel := do.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// A previous Create may have created the cluster without its external
+	// name being persisted, e.g. because the request timed out. Cluster names
+	// are unique, so such a cluster is adopted rather than attempted to be
+	// created again.
+	existing, err := dok8s.FindByName(ctx, c.client, name)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFindCreatedK8s)
+	}
+	if existing != nil && dok8s.WasCreatedFor(*existing, cr.Spec.ForProvider, cr.GetCreationTimestamp().Time) {
+		meta.SetExternalName(cr, existing.ID)
+		return managed.ExternalCreation{}, nil
+	}
+
+	k8s, _, err := c.client.Create(ctx, create)
+	if do.IsTimedOut(err) {
+		// The managed reconciler requeues failed creations, so the cluster is
+		// adopted, or created again, at a later reconcile.
+		return managed.ExternalCreation{}, errors.Wrap(err, errK8sCreateTimedOut)
+	}
 	if err != nil || k8s == nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errK8sCreateFailed)
 	}
@@ -162,8 +209,101 @@ func (c *k8sExternal) Create(ctx context.Context, mg resource.Managed) (managed.
 }
 
 func (c *k8sExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Droplets cannot be updated.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha1.DOKubernetesCluster)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotK8s)
+	}
+
+	ctx, cancel := do.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// Only the container registry integration and the labels and taints of
+	// node pools can be updated right now. Nodes can also be recycled.
+	err := c.updateRegistry(ctx, cr)
+	if err == nil {
+		err = c.updateNodePools(ctx, cr)
+	}
+	if err == nil {
+		err = c.recycleNodes(ctx, cr)
+	}
+	if do.IsTimedOut(err) {
+		// The cluster is updated again at the next poll.
+		return managed.ExternalUpdate{}, nil
+	}
+	return managed.ExternalUpdate{}, err
+}
+
+func (c *k8sExternal) updateRegistry(ctx context.Context, cr *v1alpha1.DOKubernetesCluster) error {
+	want := cr.Spec.ForProvider.RegistryIntegration
+	if want == nil || *want == cr.Status.AtProvider.RegistryEnabled {
+		return nil
+	}
+
+	req := &godo.KubernetesClusterRegistryRequest{ClusterUUIDs: []string{meta.GetExternalName(cr)}}
+	var err error
+	if *want {
+		_, err = c.client.AddRegistry(ctx, req)
+	} else {
+		_, err = c.client.RemoveRegistry(ctx, req)
+	}
+	if err != nil {
+		return errors.Wrap(err, errK8sRegistry)
+	}
+
+	cr.Status.AtProvider.RegistryEnabled = *want
+	return nil
+}
+
+// updateNodePools reconciles the labels and taints of the node pools of the
+// cluster. Labels that DigitalOcean set by itself are kept.
+func (c *k8sExternal) updateNodePools(ctx context.Context, cr *v1alpha1.DOKubernetesCluster) error {
+	for _, u := range dok8s.GenerateNodePoolUpdates(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		if _, _, err := c.client.UpdateNodePool(ctx, meta.GetExternalName(cr), u.ID, u.Request); err != nil {
+			return errors.Wrap(err, errK8sNodePool)
+		}
+	}
+	return nil
+}
+
+// recycleNodes replaces the nodes that are listed by the recycle annotation
+// of the cluster and removes the annotation, so that they are only recycled
+// once. Nodes that are already being recycled or are gone are skipped.
+func (c *k8sExternal) recycleNodes(ctx context.Context, cr *v1alpha1.DOKubernetesCluster) error {
+	ids := dok8s.NodesToRecycle(cr)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	for _, id := range ids {
+		pool, ok := dok8s.NodePoolOf(cr.Status.AtProvider, id)
+		if !ok || contains(cr.Status.AtProvider.RecyclingNodes, id) {
+			continue
+		}
+		response, err := c.client.DeleteNode(ctx, meta.GetExternalName(cr), pool, id, &godo.KubernetesNodeDeleteRequest{Replace: true})
+		if err := do.IgnoreNotFound(err, response); err != nil {
+			return errors.Wrap(err, errK8sRecycleNode)
+		}
+		cr.Status.AtProvider.RecyclingNodes = append(cr.Status.AtProvider.RecyclingNodes, id)
+	}
+
+	// Updating the cluster resets its status to the one that was last
+	// persisted, so the nodes being recycled are restored afterwards.
+	recycling := cr.Status.AtProvider.RecyclingNodes
+	meta.RemoveAnnotations(cr, dok8s.AnnotationRecycleNodes)
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return errors.Wrap(err, errK8sUpdate)
+	}
+	cr.Status.AtProvider.RecyclingNodes = recycling
+	return nil
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *k8sExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -174,6 +314,6 @@ func (c *k8sExternal) Delete(ctx context.Context, mg resource.Managed) error {
 
 	cr.Status.SetConditions(xpv1.Deleting())
 
-	response, err := c.Databases.Delete(ctx, cr.Status.AtProvider.ID)
+	response, err := c.client.Delete(ctx, cr.Status.AtProvider.ID)
 	return errors.Wrap(do.IgnoreNotFound(err, response), errK8sDeleteFailed)
 }
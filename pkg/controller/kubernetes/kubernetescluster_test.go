@@ -12,3 +12,328 @@ limitations under the License.
 */
 
 package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes/fake"
+)
+
+var clusterID = "bd5f5959-5e1e-4205-a714-a914373942af"
+
+type clusterModifier func(*v1alpha1.DOKubernetesCluster)
+
+func withClusterExternalName(name string) clusterModifier {
+	return func(r *v1alpha1.DOKubernetesCluster) { meta.SetExternalName(r, name) }
+}
+
+func withClusterSpec(p v1alpha1.DOKubernetesClusterParameters) clusterModifier {
+	return func(r *v1alpha1.DOKubernetesCluster) { r.Spec.ForProvider = p }
+}
+
+func withClusterStatus(s v1alpha1.DOKubernetesClusterObservation) clusterModifier {
+	return func(r *v1alpha1.DOKubernetesCluster) { r.Status.AtProvider = s }
+}
+
+func cluster(m ...clusterModifier) *v1alpha1.DOKubernetesCluster {
+	cr := &v1alpha1.DOKubernetesCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func Test_k8sExternal_Create(t *testing.T) {
+	errBoom := errors.New("boom")
+	created := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	params := v1alpha1.DOKubernetesClusterParameters{Region: "nyc1", MaintenancePolicy: &v1alpha1.KubernetesClusterMaintenancePolicy{}}
+	withCreated := func(r *v1alpha1.DOKubernetesCluster) { r.SetCreationTimestamp(created) }
+	list := func(clusters ...*godo.KubernetesCluster) func(context.Context, *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error) {
+		return func(context.Context, *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error) {
+			return clusters, &godo.Response{}, nil
+		}
+	}
+	blocked := func(ctx context.Context, _ *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error) {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}
+
+	type want struct {
+		externalName string
+		err          error
+	}
+	cases := map[string]struct {
+		client *fake.MockKubernetesClient
+		cr     *v1alpha1.DOKubernetesCluster
+		want   want
+	}{
+		"AdoptsCreatedCluster": {
+			client: &fake.MockKubernetesClient{
+				MockList: list(&godo.KubernetesCluster{ID: clusterID, Name: name, RegionSlug: "nyc1", CreatedAt: created.Add(time.Minute)}),
+			},
+			cr:   cluster(withCreated, withClusterSpec(params)),
+			want: want{externalName: clusterID},
+		},
+		"ExistingClusterNotAdopted": {
+			client: &fake.MockKubernetesClient{
+				MockList:   list(&godo.KubernetesCluster{ID: "older", Name: name, RegionSlug: "nyc1", CreatedAt: created.Add(-time.Hour)}),
+				MockCreate: blocked,
+			},
+			cr:   cluster(withCreated, withClusterSpec(params)),
+			want: want{err: errors.Wrap(context.DeadlineExceeded, errK8sCreateTimedOut)},
+		},
+		"FindFailed": {
+			client: &fake.MockKubernetesClient{
+				MockList: func(context.Context, *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error) {
+					return nil, nil, errBoom
+				},
+			},
+			cr:   cluster(withCreated, withClusterSpec(params)),
+			want: want{err: errors.Wrap(errBoom, errFindCreatedK8s)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &k8sExternal{client: tc.client, timeout: time.Millisecond}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("Create(...): -want external name, +got external name:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_k8sExternal_Update(t *testing.T) {
+	enabled := v1alpha1.DOKubernetesClusterParameters{RegistryIntegration: godo.Bool(true)}
+	disabled := v1alpha1.DOKubernetesClusterParameters{RegistryIntegration: godo.Bool(false)}
+	labels := v1alpha1.DOKubernetesClusterParameters{NodePools: []v1alpha1.KubernetesNodePool{
+		{Name: "pool", Count: 1, Labels: map[string]string{"team": "b"}},
+	}}
+	labelled := v1alpha1.DOKubernetesClusterObservation{NodePools: []v1alpha1.KubernetesNodePoolObservation{
+		{ID: "pool-id", Name: "pool", Count: 2, Labels: map[string]string{"team": "a", "doks.digitalocean.com/node-pool": "pool"}},
+	}}
+	registryRequest := func(ctx context.Context, req *godo.KubernetesClusterRegistryRequest) (*godo.Response, error) {
+		if diff := cmp.Diff([]string{clusterID}, req.ClusterUUIDs); diff != "" {
+			return nil, errors.New(diff)
+		}
+		return &godo.Response{}, nil
+	}
+
+	type args struct {
+		k8s kubernetes.KubernetesClient
+		cr  *v1alpha1.DOKubernetesCluster
+	}
+	type want struct {
+		cr  *v1alpha1.DOKubernetesCluster
+		err error
+	}
+	tests := map[string]struct {
+		args
+		want
+	}{
+		"EnableRegistry": {
+			args: args{
+				k8s: &fake.MockKubernetesClient{MockAddRegistry: registryRequest},
+				cr:  cluster(withClusterExternalName(clusterID), withClusterSpec(enabled)),
+			},
+			want: want{
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(enabled),
+					withClusterStatus(v1alpha1.DOKubernetesClusterObservation{RegistryEnabled: true})),
+			},
+		},
+		"DisableRegistry": {
+			args: args{
+				k8s: &fake.MockKubernetesClient{MockRemoveRegistry: registryRequest},
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(disabled),
+					withClusterStatus(v1alpha1.DOKubernetesClusterObservation{RegistryEnabled: true})),
+			},
+			want: want{
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(disabled),
+					withClusterStatus(v1alpha1.DOKubernetesClusterObservation{RegistryEnabled: false})),
+			},
+		},
+		"AlreadyEnabled": {
+			args: args{
+				k8s: &fake.MockKubernetesClient{},
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(enabled),
+					withClusterStatus(v1alpha1.DOKubernetesClusterObservation{RegistryEnabled: true})),
+			},
+			want: want{
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(enabled),
+					withClusterStatus(v1alpha1.DOKubernetesClusterObservation{RegistryEnabled: true})),
+			},
+		},
+		"EnableFailed": {
+			args: args{
+				k8s: &fake.MockKubernetesClient{
+					MockAddRegistry: func(context.Context, *godo.KubernetesClusterRegistryRequest) (*godo.Response, error) {
+						return &godo.Response{}, errors.New("")
+					},
+				},
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(enabled)),
+			},
+			want: want{
+				cr:  cluster(withClusterExternalName(clusterID), withClusterSpec(enabled)),
+				err: errors.Wrap(errors.New(""), errK8sRegistry),
+			},
+		},
+		"UpdateNodePoolLabels": {
+			args: args{
+				k8s: &fake.MockKubernetesClient{
+					MockUpdateNodePool: func(_ context.Context, cluster, pool string, req *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+						want := &godo.KubernetesNodePoolUpdateRequest{
+							Name:   "pool",
+							Count:  godo.PtrTo(2),
+							Labels: map[string]string{"team": "b", "doks.digitalocean.com/node-pool": "pool"},
+						}
+						if diff := cmp.Diff(want, req); diff != "" || cluster != clusterID || pool != "pool-id" {
+							return nil, nil, errors.New(diff)
+						}
+						return &godo.KubernetesNodePool{}, &godo.Response{}, nil
+					},
+				},
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(labels), withClusterStatus(labelled)),
+			},
+			want: want{
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(labels), withClusterStatus(labelled)),
+			},
+		},
+		"UpdateNodePoolFailed": {
+			args: args{
+				k8s: &fake.MockKubernetesClient{
+					MockUpdateNodePool: func(context.Context, string, string, *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+						return nil, &godo.Response{}, errors.New("")
+					},
+				},
+				cr: cluster(withClusterExternalName(clusterID), withClusterSpec(labels), withClusterStatus(labelled)),
+			},
+			want: want{
+				cr:  cluster(withClusterExternalName(clusterID), withClusterSpec(labels), withClusterStatus(labelled)),
+				err: errors.Wrap(errors.New(""), errK8sNodePool),
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &k8sExternal{client: tc.k8s}
+			_, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_k8sExternal_RecycleNodes(t *testing.T) {
+	withAnnotation := func(v string) clusterModifier {
+		return func(r *v1alpha1.DOKubernetesCluster) {
+			meta.AddAnnotations(r, map[string]string{kubernetes.AnnotationRecycleNodes: v})
+		}
+	}
+	observed := func(recycling ...string) v1alpha1.DOKubernetesClusterObservation {
+		return v1alpha1.DOKubernetesClusterObservation{
+			NodePools: []v1alpha1.KubernetesNodePoolObservation{
+				{ID: "pool-id", Name: "pool", Nodes: []v1alpha1.KubernetesNode{{ID: "node-a"}, {ID: "node-b"}}},
+			},
+			RecyclingNodes: recycling,
+		}
+	}
+
+	type args struct {
+		k8s  kubernetes.KubernetesClient
+		kube client.Client
+		cr   *v1alpha1.DOKubernetesCluster
+	}
+	type want struct {
+		cr       *v1alpha1.DOKubernetesCluster
+		recycled []string
+		err      error
+	}
+	tests := map[string]struct {
+		args
+		want
+	}{
+		"RecycleAndClear": {
+			args: args{
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				cr:   cluster(withClusterExternalName(clusterID), withAnnotation("node-a, gone"), withClusterStatus(observed())),
+			},
+			want: want{
+				cr:       cluster(withClusterExternalName(clusterID), withClusterStatus(observed("node-a"))),
+				recycled: []string{"node-a"},
+			},
+		},
+		"AlreadyRecycling": {
+			args: args{
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				cr:   cluster(withClusterExternalName(clusterID), withAnnotation("node-a,node-b"), withClusterStatus(observed("node-a"))),
+			},
+			want: want{
+				cr:       cluster(withClusterExternalName(clusterID), withClusterStatus(observed("node-a", "node-b"))),
+				recycled: []string{"node-b"},
+			},
+		},
+		"ClearFailed": {
+			args: args{
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errors.New(""))},
+				cr:   cluster(withClusterExternalName(clusterID), withAnnotation("node-a"), withClusterStatus(observed())),
+			},
+			want: want{
+				cr:       cluster(withClusterExternalName(clusterID), withClusterStatus(observed("node-a"))),
+				recycled: []string{"node-a"},
+				err:      errors.Wrap(errors.New(""), errK8sUpdate),
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var recycled []string
+			k8s := &fake.MockKubernetesClient{
+				MockDeleteNode: func(_ context.Context, cluster, pool, node string, req *godo.KubernetesNodeDeleteRequest) (*godo.Response, error) {
+					if cluster != clusterID || pool != "pool-id" || !req.Replace {
+						return nil, errors.New("unexpected request")
+					}
+					recycled = append(recycled, node)
+					return &godo.Response{Response: &http.Response{StatusCode: http.StatusAccepted}}, nil
+				},
+			}
+			e := &k8sExternal{client: k8s, kube: tc.kube}
+			_, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.recycled, recycled); diff != "" {
+				t.Errorf("r: -want recycled, +got recycled:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
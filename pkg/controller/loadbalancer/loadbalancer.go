@@ -20,7 +20,6 @@ import (
 	"context"
 
 	"github.com/digitalocean/godo"
-	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +34,7 @@ import (
 	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dolb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/loadbalancer"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
 )
 
 const (
@@ -45,11 +45,13 @@ const (
 	errLBCreateFailed = "creation of LoadBalancer resource has failed"
 	errLBDeleteFailed = "deletion of LoadBalancer resource has failed"
 	errLBUpdate       = "cannot update managed LoadBalancer resource"
+	errLBUpdateFailed = "update of LoadBalancer resource has failed"
+	errLBTagsFailed   = "cannot update the tags of LoadBalancer resource"
 )
 
 // SetupLB adds a controller that reconciles LB managed
 // resources.
-func SetupLB(mgr ctrl.Manager, l logging.Logger) error {
+func SetupLB(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.LBGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -57,30 +59,43 @@ func SetupLB(mgr ctrl.Manager, l logging.Logger) error {
 		For(&v1alpha1.LB{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.LBGroupVersionKind),
-			managed.WithExternalConnecter(&lbConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(do.NewObserveOnlyConnecter(&lbConnector{kube: mgr.GetClient(), readOnlySpec: o.ReadOnlySpec}, o.ObserveOnly)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithFinalizer(resource.NewAPIFinalizer(mgr.GetClient(), o.Finalizer)),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
 type lbConnector struct {
-	kube client.Client
+	kube         client.Client
+	readOnlySpec bool
 }
 
 func (c *lbConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	client, pc, err := do.ConnectWithConfig(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
-	return &lbExternal{Client: client, kube: c.kube}, nil
+	return &lbExternal{client: client.LoadBalancers, allowedRegions: pc.AllowedRegions, tags: client.Tags, kube: c.kube, readOnlySpec: c.readOnlySpec}, nil
 }
 
 type lbExternal struct {
-	kube client.Client
-	*godo.Client
+	kube         client.Client
+	client       dolb.LBClient
+	tags         do.ResourceTagsClient
+	readOnlySpec bool
+
+	// allowedRegions are the regions the ProviderConfig allows LBs to be
+	// created in. All are allowed if it is empty.
+	allowedRegions []string
+}
+
+// lbConditions maps the statuses of a load balancer to the conditions they result in.
+var lbConditions = do.StatusConditions{
+	v1alpha1.StatusNew:    xpv1.Creating,
+	v1alpha1.StatusActive: xpv1.Available,
 }
 
 func (c *lbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -95,35 +110,34 @@ func (c *lbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		}, nil
 	}
 
-	observed, response, err := c.LoadBalancers.Get(ctx, meta.GetExternalName(cr))
+	observed, response, err := c.client.Get(ctx, meta.GetExternalName(cr))
 	if err != nil {
+		if do.IsRetryable(response, err) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetLB)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
-	dolb.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errLBUpdate)
-		}
+	lateInit := cr.Spec.ForProvider.DeepCopy()
+	dolb.LateInitializeSpec(lateInit, *observed)
+	if err := do.PersistLateInit(ctx, c.kube, cr, &cr.Spec.ForProvider, lateInit, !c.readOnlySpec); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errLBUpdate)
 	}
 
 	cr.Status.AtProvider = v1alpha1.LBObservation{
 		CreationTimestamp: observed.Created,
 		ID:                observed.ID,
+		SizeUnit:          int(observed.SizeUnit),
 		Status:            observed.Status,
 	}
 
-	switch cr.Status.AtProvider.Status {
-	case v1alpha1.StatusNew:
-		cr.SetConditions(xpv1.Creating())
-	case v1alpha1.StatusActive:
-		cr.SetConditions(xpv1.Available())
-	}
+	do.SetStatusCondition(cr, cr.Status.AtProvider.Status, lbConditions)
+
+	tagsUpToDate := do.DiffManagedTags(cr, cr.Spec.ForProvider.Tags, observed.Tags, cr.Spec.ForProvider.IgnoreTagsMatching).UpToDate(cr)
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceUpToDate: dolb.IsUpToDate(cr.Spec.ForProvider, *observed) && tagsUpToDate,
 	}, nil
 }
 
@@ -135,6 +149,14 @@ func (c *lbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 
 	cr.Status.SetConditions(xpv1.Creating())
 
+	if err := dolb.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errLBCreateFailed)
+	}
+
+	if err := do.ValidateRegion(cr.Spec.ForProvider.Region, c.allowedRegions); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errLBCreateFailed)
+	}
+
 	name := meta.GetExternalName(cr)
 	if meta.GetExternalName(cr) == "" {
 		name = cr.GetName()
@@ -143,7 +165,7 @@ func (c *lbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 	create := &godo.LoadBalancerRequest{}
 	dolb.GenerateLoadBalancer(name, cr.Spec.ForProvider, create)
 
-	lb, _, err := c.LoadBalancers.Create(ctx, create)
+	lb, _, err := c.client.Create(ctx, create)
 	if err != nil || lb == nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errLBCreateFailed)
 	}
@@ -156,8 +178,44 @@ func (c *lbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 }
 
 func (c *lbExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Droplets cannot be updated.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha1.LB)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLB)
+	}
+
+	if err := dolb.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errLBUpdateFailed)
+	}
+
+	observed, _, err := c.client.Get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetLB)
+	}
+
+	// Updates replace the whole LB, so they are only sent if its spec drifted
+	// rather than just its tags.
+	if !dolb.IsUpToDate(cr.Spec.ForProvider, *observed) {
+		if _, _, err := c.client.Update(ctx, observed.ID, dolb.GenerateUpdate(cr.Spec.ForProvider, *observed)); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errLBUpdateFailed)
+		}
+	}
+
+	return managed.ExternalUpdate{}, c.updateTags(ctx, cr, *observed)
+}
+
+// updateTags reconciles the tags of the supplied observed LB, then records
+// the desired tags as the ones the provider manages.
+func (c *lbExternal) updateTags(ctx context.Context, cr *v1alpha1.LB, observed godo.LoadBalancer) error {
+	d := do.DiffManagedTags(cr, cr.Spec.ForProvider.Tags, observed.Tags, cr.Spec.ForProvider.IgnoreTagsMatching)
+	r := godo.Resource{ID: observed.ID, Type: godo.LoadBalancerResourceType}
+	if err := do.UpdateTags(ctx, c.tags, r, d.Add, d.Remove); err != nil {
+		return errors.Wrap(err, errLBTagsFailed)
+	}
+	if d.Recorded(cr) {
+		return nil
+	}
+	do.SetManagedTags(cr, d.Managed)
+	return errors.Wrap(c.kube.Update(ctx, cr), errLBUpdate)
 }
 
 func (c *lbExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -168,6 +226,6 @@ func (c *lbExternal) Delete(ctx context.Context, mg resource.Managed) error {
 
 	cr.Status.SetConditions(xpv1.Deleting())
 
-	response, err := c.LoadBalancers.Delete(ctx, cr.Status.AtProvider.ID)
+	response, err := c.client.Delete(ctx, cr.Status.AtProvider.ID)
 	return errors.Wrap(do.IgnoreNotFound(err, response), errLBDeleteFailed)
 }
@@ -0,0 +1,315 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dofake "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+	dolb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/loadbalancer"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/loadbalancer/fake"
+)
+
+var (
+	name = "test"
+	id   = "4de7ac8b-495b-4884-9a69-1050c6793cd6"
+)
+
+type args struct {
+	lb   dolb.LBClient
+	kube client.Client
+	cr   *v1alpha1.LB
+}
+
+type lbModifier func(*v1alpha1.LB)
+
+func withExternalName(name string) lbModifier {
+	return func(r *v1alpha1.LB) { meta.SetExternalName(r, name) }
+}
+
+func withSpec(p v1alpha1.LBParameters) lbModifier {
+	return func(r *v1alpha1.LB) { r.Spec.ForProvider = p }
+}
+
+func lb(m ...lbModifier) *v1alpha1.LB {
+	cr := &v1alpha1.LB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func Test_lbExternal_Update(t *testing.T) {
+	type want struct {
+		err error
+	}
+	observed := &godo.LoadBalancer{ID: id, Name: name, Algorithm: dolb.AlgorithmRoundRobin, DropletIDs: []int{1}}
+	tests := map[string]struct {
+		args
+		want
+	}{
+		"ReconcilesAlgorithm": {
+			args: args{
+				lb: &fake.MockLBClient{
+					MockGet: func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error) {
+						return observed, &godo.Response{}, nil
+					},
+					MockUpdate: func(_ context.Context, _ string, req *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+						if req.Algorithm != dolb.AlgorithmLeastConnections || len(req.DropletIDs) != 1 {
+							return nil, &godo.Response{}, errors.Errorf("unexpected request %+v", req)
+						}
+						return observed, &godo.Response{}, nil
+					},
+				},
+				cr: lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmLeastConnections})),
+			},
+			want: want{},
+		},
+		"ReconcilesSizeUnit": {
+			args: args{
+				lb: &fake.MockLBClient{
+					MockGet: func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error) {
+						return &godo.LoadBalancer{ID: id, Name: name, Algorithm: dolb.AlgorithmRoundRobin, SizeSlug: dolb.SizeSmall, SizeUnit: 1}, &godo.Response{}, nil
+					},
+					MockUpdate: func(_ context.Context, _ string, req *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+						if req.SizeUnit != 3 || req.SizeSlug != "" {
+							return nil, &godo.Response{}, errors.Errorf("unexpected request %+v", req)
+						}
+						return observed, &godo.Response{}, nil
+					},
+				},
+				cr: lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmRoundRobin, SizeUnit: godo.Int(3)})),
+			},
+			want: want{},
+		},
+		"InvalidSizeUnit": {
+			args: args{
+				lb: &fake.MockLBClient{},
+				cr: lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmRoundRobin, Size: godo.String(dolb.SizeSmall), SizeUnit: godo.Int(3)})),
+			},
+			want: want{
+				err: errors.Wrap(dolb.ValidateSize(v1alpha1.LBParameters{Size: godo.String(dolb.SizeSmall), SizeUnit: godo.Int(3)}), errLBUpdateFailed),
+			},
+		},
+		"UnsupportedAlgorithm": {
+			args: args{
+				lb: &fake.MockLBClient{},
+				cr: lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: "weighted"})),
+			},
+			want: want{
+				err: errors.Wrap(dolb.ValidateAlgorithm("weighted"), errLBUpdateFailed),
+			},
+		},
+		"UpdateFailed": {
+			args: args{
+				lb: &fake.MockLBClient{
+					MockGet: func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error) {
+						return observed, &godo.Response{}, nil
+					},
+					MockUpdate: func(context.Context, string, *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+						return nil, &godo.Response{}, errors.New("")
+					},
+				},
+				cr: lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmLeastConnections})),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(""), errLBUpdateFailed),
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &lbExternal{kube: tc.kube, client: tc.lb}
+			_, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_lbExternal_Observe(t *testing.T) {
+	type want struct {
+		result managed.ExternalObservation
+		err    error
+	}
+	tests := map[string]struct {
+		args
+		want
+	}{
+		"AlgorithmDrift": {
+			args: args{
+				lb: &fake.MockLBClient{
+					MockGet: func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error) {
+						return &godo.LoadBalancer{ID: id, Algorithm: dolb.AlgorithmRoundRobin, Tags: []string{}}, &godo.Response{}, nil
+					},
+				},
+				kube: &test.MockClient{MockUpdate: test.NewMockClient().Update},
+				cr:   lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmLeastConnections})),
+			},
+			want: want{
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"SizeUnitDrift": {
+			args: args{
+				lb: &fake.MockLBClient{
+					MockGet: func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error) {
+						return &godo.LoadBalancer{ID: id, Algorithm: dolb.AlgorithmRoundRobin, SizeUnit: 1}, &godo.Response{}, nil
+					},
+				},
+				kube: &test.MockClient{MockUpdate: test.NewMockClient().Update},
+				cr:   lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmRoundRobin, SizeUnit: godo.Int(2)})),
+			},
+			want: want{
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"UpToDate": {
+			args: args{
+				lb: &fake.MockLBClient{
+					MockGet: func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error) {
+						return &godo.LoadBalancer{ID: id, Algorithm: dolb.AlgorithmRoundRobin}, &godo.Response{}, nil
+					},
+				},
+				kube: &test.MockClient{MockUpdate: test.NewMockClient().Update},
+				cr:   lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmRoundRobin})),
+			},
+			want: want{
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &lbExternal{kube: tc.kube, client: tc.lb}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_lbExternal_UpdateTags(t *testing.T) {
+	type want struct {
+		added   []string
+		removed []string
+		managed []string
+		err     error
+	}
+	tests := map[string]struct {
+		desired  []string
+		managed  []string
+		observed []string
+		want     want
+	}{
+		"Add": {
+			desired:  []string{"a", "b"},
+			observed: []string{"external"},
+			want:     want{added: []string{"a", "b"}, managed: []string{"a", "b"}},
+		},
+		"Remove": {
+			managed:  []string{"a", "b"},
+			observed: []string{"a", "b", "external"},
+			want:     want{removed: []string{"a", "b"}},
+		},
+		"Mixed": {
+			desired:  []string{"a", "c"},
+			managed:  []string{"a", "b"},
+			observed: []string{"a", "b", "external"},
+			want:     want{added: []string{"c"}, removed: []string{"b"}, managed: []string{"a", "c"}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			observed := &godo.LoadBalancer{ID: id, Algorithm: dolb.AlgorithmRoundRobin, Tags: tc.observed}
+			var added, removed []string
+			tags := &dofake.MockResourceTagsClient{
+				MockCreate: func(_ context.Context, req *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error) {
+					return &godo.Tag{Name: req.Name}, &godo.Response{}, nil
+				},
+				MockTagResources: func(_ context.Context, tag string, req *godo.TagResourcesRequest) (*godo.Response, error) {
+					if diff := cmp.Diff([]godo.Resource{{ID: id, Type: godo.LoadBalancerResourceType}}, req.Resources); diff != "" {
+						return nil, errors.New(diff)
+					}
+					added = append(added, tag)
+					return &godo.Response{}, nil
+				},
+				MockUntagResources: func(_ context.Context, tag string, req *godo.UntagResourcesRequest) (*godo.Response, error) {
+					if diff := cmp.Diff([]godo.Resource{{ID: id, Type: godo.LoadBalancerResourceType}}, req.Resources); diff != "" {
+						return nil, errors.New(diff)
+					}
+					removed = append(removed, tag)
+					return &godo.Response{}, nil
+				},
+			}
+			cr := lb(withExternalName(id), withSpec(v1alpha1.LBParameters{Algorithm: dolb.AlgorithmRoundRobin, Tags: tc.desired}))
+			do.SetManagedTags(cr, tc.managed)
+
+			e := &lbExternal{
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				client: &fake.MockLBClient{
+					MockGet: func(context.Context, string) (*godo.LoadBalancer, *godo.Response, error) {
+						return observed, &godo.Response{}, nil
+					},
+					// Only the tags drifted, so the LB itself isn't updated.
+					MockUpdate: func(context.Context, string, *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+						return nil, nil, errors.New("should not be called")
+					},
+				},
+				tags: tags,
+			}
+			_, err := e.Update(context.Background(), cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.added, added); diff != "" {
+				t.Errorf("TagResources: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.removed, removed); diff != "" {
+				t.Errorf("UntagResources: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.managed, do.GetManagedTags(cr)); diff != "" {
+				t.Errorf("GetManagedTags: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the options that are shared by all of the
+// DigitalOcean managed resource controllers.
+package options
+
+import "time"
+
+// DefaultFinalizer is the finalizer crossplane-runtime adds to managed
+// resources unless configured otherwise.
+const DefaultFinalizer = "finalizer.managedresource.crossplane.io"
+
+// Options configure the DigitalOcean managed resource controllers.
+type Options struct {
+	// Finalizer is added to managed resources to block their deletion until
+	// the external resource is deleted. Provider installations sharing a
+	// cluster must use different finalizers.
+	Finalizer string
+
+	// ReadOnlySpec stops the controllers writing values late-initialized
+	// from DigitalOcean back to the spec of managed resources. The values are
+	// reported by a LateInitialized status condition instead, so that the
+	// spec stays as declared in tools like Argo CD or Flux.
+	ReadOnlySpec bool
+
+	// OperationTimeout bounds each call that creates or updates an external
+	// resource, so that a stuck DigitalOcean API call is cancelled and retried
+	// at a later reconcile rather than blocking a worker. Zero means calls are
+	// only bounded by the timeout of the reconcile itself.
+	OperationTimeout time.Duration
+
+	// ConnectionSecretNamespace is the namespace connection secrets are
+	// written to when a managed resource doesn't specify one. Empty means
+	// managed resources must specify the namespace themselves.
+	ConnectionSecretNamespace string
+
+	// SkipAvailabilityChecks stops the controllers checking that the
+	// requested size is available in the requested region before creating a
+	// resource, which saves an API call per creation.
+	SkipAvailabilityChecks bool
+
+	// RenderObservedParameters makes the controllers render the parameters of
+	// external resources as observed on DigitalOcean into the status of
+	// managed resources, in the shape of their spec, so that the two can be
+	// compared.
+	RenderObservedParameters bool
+
+	// ObserveOnly stops the controllers creating, updating or deleting
+	// external resources. They still observe them and report drift in the
+	// status of managed resources, which can't be deleted until the provider
+	// is no longer observe-only.
+	ObserveOnly bool
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spaces
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/spaces/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dospaces "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/spaces"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/options"
+)
+
+const (
+	// Error strings.
+	errNotKey = "managed resource is not a Spaces Key resource"
+	errGetKey = "cannot get a Spaces Key"
+
+	errKeyCreateFailed = "creation of Spaces Key resource has failed"
+	errKeyUpdateFailed = "update of Spaces Key resource has failed"
+	errKeyDeleteFailed = "deletion of Spaces Key resource has failed"
+)
+
+// SetupSpacesKey adds a controller that reconciles Spaces Key managed
+// resources.
+func SetupSpacesKey(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.SpacesKeyGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.DOSpacesKey{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SpacesKeyGroupVersionKind),
+			managed.WithExternalConnecter(do.NewObserveOnlyConnecter(&keyConnector{kube: mgr.GetClient()}, o.ObserveOnly)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(do.NewChecksumPublisher(managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), do.NewDefaultConnectionSecretNamespace(mgr.GetClient(), o.ConnectionSecretNamespace)),
+			managed.WithFinalizer(resource.NewAPIFinalizer(mgr.GetClient(), o.Finalizer)),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type keyConnector struct {
+	kube client.Client
+}
+
+func (c *keyConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	client, err := do.Connect(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &keyExternal{client: dospaces.NewKeyClient(client)}, nil
+}
+
+type keyExternal struct {
+	client dospaces.KeyClient
+}
+
+func (c *keyExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DOSpacesKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotKey)
+	}
+
+	// The external name is the access key ID, which isn't known until the
+	// key has been created.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.client.Get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		if do.IsRetryable(response, err) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetKey)
+	}
+
+	cr.Status.AtProvider = v1alpha1.DOSpacesKeyObservation{
+		AccessKeyID: observed.AccessKey,
+		CreatedAt:   observed.CreatedAt,
+	}
+	cr.Status.SetConditions(xpv1.Available())
+
+	// The secret key is only returned when the key is created, so only the
+	// access key ID is published here. The secret key that was published
+	// at creation is left in the connection secret.
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  dospaces.IsUpToDate(cr.Spec.ForProvider, *observed),
+		ConnectionDetails: dospaces.GenerateConnectionDetails(observed),
+	}, nil
+}
+
+func (c *keyExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DOSpacesKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotKey)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	if err := dospaces.ValidateKey(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errKeyCreateFailed)
+	}
+
+	key, _, err := c.client.Create(ctx, dospaces.GenerateKey(cr.GetName(), cr.Spec.ForProvider))
+	if err != nil || key == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errKeyCreateFailed)
+	}
+
+	meta.SetExternalName(cr, key.AccessKey)
+
+	// DigitalOcean never reveals the secret key again, so it must be
+	// published now.
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    dospaces.GenerateConnectionDetails(key),
+	}, nil
+}
+
+func (c *keyExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DOSpacesKey)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotKey)
+	}
+
+	if err := dospaces.ValidateKey(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errKeyUpdateFailed)
+	}
+
+	_, _, err := c.client.Update(ctx, meta.GetExternalName(cr), dospaces.GenerateKey(cr.GetName(), cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errKeyUpdateFailed)
+}
+
+func (c *keyExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DOSpacesKey)
+	if !ok {
+		return errors.New(errNotKey)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.client.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errKeyDeleteFailed)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spaces
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/spaces/v1alpha1"
+	dospaces "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/spaces"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/spaces/fake"
+)
+
+var (
+	keyName   = "uploads"
+	accessKey = "DO00QWERTYUIOPASDFGH"
+	secretKey = "s3cr3t"
+)
+
+func key(p v1alpha1.DOSpacesKeyParameters, externalName string) *v1alpha1.DOSpacesKey {
+	cr := &v1alpha1.DOSpacesKey{}
+	cr.SetName(keyName)
+	meta.SetExternalName(cr, externalName)
+	cr.Spec.ForProvider = p
+	return cr
+}
+
+func readWrite(bucket string) v1alpha1.DOSpacesKeyParameters {
+	return v1alpha1.DOSpacesKeyParameters{Grants: []v1alpha1.DOSpacesKeyGrant{{Bucket: bucket, Permission: v1alpha1.PermissionReadWrite}}}
+}
+
+func Test_keyExternal_Observe(t *testing.T) {
+	observed := &dospaces.Key{
+		Name:      keyName,
+		Grants:    []dospaces.Grant{{Bucket: "uploads", Permission: v1alpha1.PermissionReadWrite}},
+		AccessKey: accessKey,
+		CreatedAt: "2024-01-01T00:00:00Z",
+	}
+	published := managed.ConnectionDetails{dospaces.ConnectionSecretAccessKeyIDKey: []byte(accessKey)}
+
+	type want struct {
+		obs managed.ExternalObservation
+		err error
+	}
+
+	tests := map[string]struct {
+		cr   *v1alpha1.DOSpacesKey
+		get  func(context.Context, string) (*dospaces.Key, *godo.Response, error)
+		want want
+	}{
+		"NotCreated": {
+			cr: key(readWrite("uploads"), ""),
+		},
+		"UpToDate": {
+			cr: key(readWrite("uploads"), accessKey),
+			get: func(context.Context, string) (*dospaces.Key, *godo.Response, error) {
+				return observed, &godo.Response{}, nil
+			},
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: published}},
+		},
+		"GrantsDrifted": {
+			cr: key(readWrite("backups"), accessKey),
+			get: func(context.Context, string) (*dospaces.Key, *godo.Response, error) {
+				return observed, &godo.Response{}, nil
+			},
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ConnectionDetails: published}},
+		},
+		"Revoked": {
+			cr: key(readWrite("uploads"), accessKey),
+			get: func(context.Context, string) (*dospaces.Key, *godo.Response, error) {
+				return nil, &godo.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+			},
+		},
+		"GetFailed": {
+			cr: key(readWrite("uploads"), accessKey),
+			get: func(context.Context, string) (*dospaces.Key, *godo.Response, error) {
+				return nil, &godo.Response{Response: &http.Response{StatusCode: http.StatusBadRequest}}, errors.New("boom")
+			},
+			want: want{err: errors.Wrap(errors.New("boom"), errGetKey)},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := keyExternal{client: &fake.MockKeyClient{MockGet: tc.get}}
+			obs, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_keyExternal_Create(t *testing.T) {
+	type want struct {
+		ec           managed.ExternalCreation
+		externalName string
+		err          error
+	}
+
+	tests := map[string]struct {
+		cr     *v1alpha1.DOSpacesKey
+		create func(context.Context, *dospaces.KeyRequest) (*dospaces.Key, *godo.Response, error)
+		want   want
+	}{
+		"PublishesSecret": {
+			cr: key(readWrite("uploads"), ""),
+			create: func(_ context.Context, kr *dospaces.KeyRequest) (*dospaces.Key, *godo.Response, error) {
+				want := &dospaces.KeyRequest{Name: keyName, Grants: []dospaces.Grant{{Bucket: "uploads", Permission: v1alpha1.PermissionReadWrite}}}
+				if diff := cmp.Diff(want, kr); diff != "" {
+					return nil, nil, errors.New(diff)
+				}
+				return &dospaces.Key{Name: kr.Name, Grants: kr.Grants, AccessKey: accessKey, SecretKey: secretKey}, &godo.Response{}, nil
+			},
+			want: want{
+				ec: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						dospaces.ConnectionSecretAccessKeyIDKey:     []byte(accessKey),
+						dospaces.ConnectionSecretSecretAccessKeyKey: []byte(secretKey),
+					},
+				},
+				externalName: accessKey,
+			},
+		},
+		"InvalidGrant": {
+			cr:   key(v1alpha1.DOSpacesKeyParameters{Grants: []v1alpha1.DOSpacesKeyGrant{{Permission: v1alpha1.PermissionRead}}}, ""),
+			want: want{err: errors.Wrap(errors.Errorf("a bucket is required for %q grants", v1alpha1.PermissionRead), errKeyCreateFailed)},
+		},
+		"CreateFailed": {
+			cr: key(readWrite("uploads"), ""),
+			create: func(context.Context, *dospaces.KeyRequest) (*dospaces.Key, *godo.Response, error) {
+				return nil, nil, errors.New("boom")
+			},
+			want: want{err: errors.Wrap(errors.New("boom"), errKeyCreateFailed)},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := keyExternal{client: &fake.MockKeyClient{MockCreate: tc.create}}
+			ec, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ec, ec); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_keyExternal_Delete(t *testing.T) {
+	tests := map[string]struct {
+		delete func(context.Context, string) (*godo.Response, error)
+		want   error
+	}{
+		"Revoked": {
+			delete: func(_ context.Context, ak string) (*godo.Response, error) {
+				if ak != accessKey {
+					return nil, errors.New("unexpected key")
+				}
+				return &godo.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+			},
+		},
+		"AlreadyGone": {
+			delete: func(context.Context, string) (*godo.Response, error) {
+				return &godo.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+			},
+		},
+		"DeleteFailed": {
+			delete: func(context.Context, string) (*godo.Response, error) {
+				return &godo.Response{Response: &http.Response{StatusCode: http.StatusBadRequest}}, errors.New("boom")
+			},
+			want: errors.Wrap(errors.New("boom"), errKeyDeleteFailed),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := keyExternal{client: &fake.MockKeyClient{MockDelete: tc.delete}}
+			err := e.Delete(context.Background(), key(readWrite("uploads"), accessKey))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}